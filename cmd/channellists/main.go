@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/application"
+	appconfig "github.com/marcelovmendes/playswap/conversion-worker/internal/config"
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/domain"
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/infrastructure/awsconfig"
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/infrastructure/dynamodb"
+)
+
+const usage = `usage:
+  channellists list   [-user ID]
+  channellists add    -kind ALLOW|BLOCK -target CHANNEL|VIDEO -value VALUE [-user ID] [-note TEXT]
+  channellists remove -kind ALLOW|BLOCK -target CHANNEL|VIDEO -value VALUE [-user ID]
+
+Entries without -user apply to every user.`
+
+func main() {
+	log.SetFlags(0)
+	if len(os.Args) < 2 {
+		log.Fatal(usage)
+	}
+
+	ctx := context.Background()
+	cfg := appconfig.Load()
+	awsCfg, err := awsconfig.Load(ctx, cfg.AWS)
+	if err != nil {
+		log.Fatal("failed to load AWS config: ", err)
+	}
+	repo := dynamodb.NewChannelListRepository(awsCfg, cfg.AWS.DynamoDBChannelListTable)
+
+	command, args := os.Args[1], os.Args[2:]
+	switch command {
+	case "list":
+		err = list(ctx, repo, args)
+	case "add":
+		err = add(ctx, repo, args)
+	case "remove":
+		err = remove(ctx, repo, args)
+	default:
+		log.Fatal(usage)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func list(ctx context.Context, repo application.ChannelListRepository, args []string) error {
+	fs := flag.NewFlagSet("list", flag.ExitOnError)
+	user := fs.String("user", "", "user ID; lists global entries when empty")
+	fs.Parse(args)
+
+	entries, err := repo.ListByScope(ctx, scope(*user))
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "KIND\tTARGET\tVALUE\tNOTE\tCREATED")
+	for _, e := range entries {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", e.Kind, e.Target, e.Value, e.Note, e.CreatedAt.Format("2006-01-02"))
+	}
+	return w.Flush()
+}
+
+func add(ctx context.Context, repo application.ChannelListRepository, args []string) error {
+	fs := flag.NewFlagSet("add", flag.ExitOnError)
+	entry, note := entryFlags(fs)
+	fs.Parse(args)
+
+	e, err := entry(*note)
+	if err != nil {
+		return err
+	}
+	if err := repo.Put(ctx, e); err != nil {
+		return err
+	}
+	log.Printf("added %s %s %q to %s", e.Kind, e.Target, e.Value, e.Scope)
+	return nil
+}
+
+func remove(ctx context.Context, repo application.ChannelListRepository, args []string) error {
+	fs := flag.NewFlagSet("remove", flag.ExitOnError)
+	entry, _ := entryFlags(fs)
+	fs.Parse(args)
+
+	e, err := entry("")
+	if err != nil {
+		return err
+	}
+	if err := repo.Delete(ctx, e); err != nil {
+		return err
+	}
+	log.Printf("removed %s %s %q from %s", e.Kind, e.Target, e.Value, e.Scope)
+	return nil
+}
+
+func entryFlags(fs *flag.FlagSet) (func(note string) (*domain.ChannelListEntry, error), *string) {
+	kind := fs.String("kind", "", "ALLOW or BLOCK")
+	target := fs.String("target", "", "CHANNEL or VIDEO")
+	value := fs.String("value", "", "channel ID or video ID")
+	user := fs.String("user", "", "user ID; applies to every user when empty")
+	note := fs.String("note", "", "why the entry exists")
+
+	return func(note string) (*domain.ChannelListEntry, error) {
+		return domain.NewChannelListEntry(scope(*user), domain.ChannelListKind(strings.ToUpper(*kind)),
+			domain.ChannelListTarget(strings.ToUpper(*target)), *value, note)
+	}, note
+}
+
+func scope(user string) string {
+	if user == "" {
+		return domain.ChannelListScopeGlobal
+	}
+	return user
+}
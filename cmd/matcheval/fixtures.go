@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/domain"
+)
+
+type fixtureTrack struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	Artist     string   `json:"artist"`
+	Album      string   `json:"album,omitempty"`
+	DurationMs int      `json:"durationMs,omitempty"`
+	ISRC       string   `json:"isrc,omitempty"`
+	Explicit   bool     `json:"explicit,omitempty"`
+	Artists    []string `json:"artists,omitempty"`
+	Kind       string   `json:"kind,omitempty"`
+	Official   bool     `json:"officialChannel,omitempty"`
+}
+
+type fixtureCase struct {
+	Name          string                    `json:"name"`
+	Source        fixtureTrack              `json:"source"`
+	ISRCResult    *fixtureTrack             `json:"isrcResult,omitempty"`
+	SearchResults map[string][]fixtureTrack `json:"searchResults"`
+	ExpectedID    string                    `json:"expectedId"`
+}
+
+type fixtureFile struct {
+	Options domain.MatchOptions `json:"options"`
+	Cases   []fixtureCase       `json:"cases"`
+}
+
+func loadFixtures(path string) (*fixtureFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixtures: %w", err)
+	}
+
+	var fixtures fixtureFile
+	if err := json.Unmarshal(data, &fixtures); err != nil {
+		return nil, fmt.Errorf("failed to parse fixtures: %w", err)
+	}
+	if err := fixtures.Options.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid fixture options: %w", err)
+	}
+
+	return &fixtures, nil
+}
+
+func (f fixtureTrack) toTrack(platform domain.Platform) (*domain.Track, error) {
+	track, err := domain.NewTrack(f.Name, f.Artist, platform, f.ID)
+	if err != nil {
+		return nil, err
+	}
+	return track.WithAlbum(f.Album).WithDuration(f.DurationMs).WithISRC(f.ISRC).WithExplicit(f.Explicit).WithArtists(f.Artists).
+		WithKind(domain.ResultKind(f.Kind)).WithOfficialChannel(f.Official), nil
+}
+
+type fixtureClient struct {
+	isrcResults   map[string]*domain.Track
+	searchResults map[string][]*domain.Track
+}
+
+func newFixtureClient(c fixtureCase) (*fixtureClient, error) {
+	client := &fixtureClient{
+		isrcResults:   make(map[string]*domain.Track),
+		searchResults: make(map[string][]*domain.Track),
+	}
+
+	if c.ISRCResult != nil {
+		track, err := c.ISRCResult.toTrack(domain.PlatformYouTube)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ISRC result: %w", err)
+		}
+		client.isrcResults[c.Source.ISRC] = track
+	}
+
+	for query, results := range c.SearchResults {
+		for _, result := range results {
+			track, err := result.toTrack(domain.PlatformYouTube)
+			if err != nil {
+				return nil, fmt.Errorf("invalid search result for %q: %w", query, err)
+			}
+			client.searchResults[query] = append(client.searchResults[query], track)
+		}
+	}
+
+	return client, nil
+}
+
+func (c *fixtureClient) SearchByISRC(ctx context.Context, isrc, market, sessionID string) (*domain.Track, error) {
+	return c.isrcResults[isrc], nil
+}
+
+func (c *fixtureClient) SearchTrack(ctx context.Context, track, artist, market, sessionID string) ([]*domain.Track, error) {
+	return c.searchResults[track+"|"+artist], nil
+}
+
+func (c *fixtureClient) CreatePlaylist(ctx context.Context, name, description, sessionID string) (string, string, error) {
+	return "", "", errors.New("not supported by fixtures")
+}
+
+func (c *fixtureClient) AddVideosToPlaylist(ctx context.Context, playlistID string, videoIDs []string, sessionID string) ([]domain.VideoInsertResult, error) {
+	return nil, errors.New("not supported by fixtures")
+}
+
+func (c *fixtureClient) ReplaceVideoInPlaylist(ctx context.Context, playlistID, oldVideoID, newVideoID, sessionID string) error {
+	return errors.New("not supported by fixtures")
+}
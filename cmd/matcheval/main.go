@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"io"
+	"log"
+	"os"
+
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/application"
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/domain"
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/infrastructure/scoringmodel"
+)
+
+func main() {
+	fixturesPath := flag.String("fixtures", "cmd/matcheval/testdata/fixtures.json", "labeled fixture file")
+	baselinePath := flag.String("baseline", "cmd/matcheval/testdata/baseline.json", "baseline report to diff against")
+	updateBaseline := flag.Bool("update-baseline", false, "overwrite the baseline with this run")
+	weightsPath := flag.String("weights", "", "trained scoring weights to evaluate")
+	verbose := flag.Bool("v", false, "show matcher debug logs")
+	flag.Parse()
+
+	logger := log.New(os.Stderr, "", 0)
+	if !*verbose {
+		log.SetOutput(io.Discard)
+	}
+
+	fixtures, err := loadFixtures(*fixturesPath)
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	var opts []application.MatcherOption
+	if *weightsPath != "" {
+		model, err := scoringmodel.Load(*weightsPath)
+		if err != nil {
+			logger.Fatal(err)
+		}
+		opts = append(opts, application.WithScoringWeights(model.Weights))
+	}
+
+	results, err := evaluate(context.Background(), fixtures, opts...)
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	current := newReport(results)
+	current.print(os.Stdout)
+
+	if *updateBaseline {
+		if err := current.save(*baselinePath); err != nil {
+			logger.Fatal(err)
+		}
+		logger.Printf("baseline written to %s", *baselinePath)
+		return
+	}
+
+	baseline, err := loadBaseline(*baselinePath)
+	if err != nil {
+		logger.Printf("skipping baseline diff: %v", err)
+		return
+	}
+
+	if current.diff(os.Stdout, baseline) {
+		logger.Fatal("matching quality regressed against baseline")
+	}
+}
+
+func evaluate(ctx context.Context, fixtures *fixtureFile, opts ...application.MatcherOption) ([]caseResult, error) {
+	results := make([]caseResult, 0, len(fixtures.Cases))
+
+	for _, c := range fixtures.Cases {
+		client, err := newFixtureClient(c)
+		if err != nil {
+			return nil, err
+		}
+
+		source, err := c.Source.toTrack(domain.PlatformSpotify)
+		if err != nil {
+			return nil, err
+		}
+
+		matcher := application.NewMatcher(client, opts...)
+		match := matcher.MatchTracks(ctx, []*domain.Track{source}, "", fixtures.Options, 1, nil)[0]
+
+		actualID := ""
+		if match.IsMatched() && match.TargetTrack != nil {
+			actualID = match.TargetTrack.PlatformID
+		}
+
+		results = append(results, caseResult{
+			Name:       c.Name,
+			ExpectedID: c.ExpectedID,
+			ActualID:   actualID,
+			Confidence: match.Confidence,
+			Outcome:    classify(c.ExpectedID, actualID),
+		})
+	}
+
+	return results, nil
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/domain"
+)
+
+type outcome string
+
+const (
+	outcomeCorrect       outcome = "correct"
+	outcomeWrong         outcome = "wrong"
+	outcomeMissed        outcome = "missed"
+	outcomeFalsePositive outcome = "false_positive"
+	outcomeTrueNegative  outcome = "true_negative"
+)
+
+var outcomes = []outcome{outcomeCorrect, outcomeWrong, outcomeMissed, outcomeFalsePositive, outcomeTrueNegative}
+
+var tiers = []domain.MatchConfidence{
+	domain.MatchConfidenceHigh,
+	domain.MatchConfidenceMedium,
+	domain.MatchConfidenceLow,
+	domain.MatchConfidenceNone,
+}
+
+type caseResult struct {
+	Name       string                 `json:"name"`
+	ExpectedID string                 `json:"expectedId,omitempty"`
+	ActualID   string                 `json:"actualId,omitempty"`
+	Confidence domain.MatchConfidence `json:"confidence"`
+	Outcome    outcome                `json:"outcome"`
+}
+
+type report struct {
+	Precision float64                                    `json:"precision"`
+	Recall    float64                                    `json:"recall"`
+	Confusion map[domain.MatchConfidence]map[outcome]int `json:"confusion"`
+	Cases     []caseResult                               `json:"cases"`
+}
+
+func classify(expectedID, actualID string) outcome {
+	switch {
+	case expectedID == "" && actualID == "":
+		return outcomeTrueNegative
+	case expectedID == "":
+		return outcomeFalsePositive
+	case actualID == "":
+		return outcomeMissed
+	case expectedID == actualID:
+		return outcomeCorrect
+	default:
+		return outcomeWrong
+	}
+}
+
+func newReport(results []caseResult) *report {
+	r := &report{
+		Confusion: make(map[domain.MatchConfidence]map[outcome]int),
+		Cases:     results,
+	}
+
+	predicted, expected, correct := 0, 0, 0
+	for _, result := range results {
+		if r.Confusion[result.Confidence] == nil {
+			r.Confusion[result.Confidence] = make(map[outcome]int)
+		}
+		r.Confusion[result.Confidence][result.Outcome]++
+
+		if result.ActualID != "" {
+			predicted++
+		}
+		if result.ExpectedID != "" {
+			expected++
+		}
+		if result.Outcome == outcomeCorrect {
+			correct++
+		}
+	}
+
+	if predicted > 0 {
+		r.Precision = float64(correct) / float64(predicted)
+	}
+	if expected > 0 {
+		r.Recall = float64(correct) / float64(expected)
+	}
+
+	return r
+}
+
+func (r *report) print(w io.Writer) {
+	fmt.Fprintf(w, "cases:     %d\n", len(r.Cases))
+	fmt.Fprintf(w, "precision: %.3f\n", r.Precision)
+	fmt.Fprintf(w, "recall:    %.3f\n\n", r.Recall)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprint(tw, "tier")
+	for _, o := range outcomes {
+		fmt.Fprintf(tw, "\t%s", o)
+	}
+	fmt.Fprintln(tw)
+	for _, tier := range tiers {
+		fmt.Fprint(tw, tier)
+		for _, o := range outcomes {
+			fmt.Fprintf(tw, "\t%d", r.Confusion[tier][o])
+		}
+		fmt.Fprintln(tw)
+	}
+	tw.Flush()
+
+	for _, result := range r.Cases {
+		if result.Outcome == outcomeWrong || result.Outcome == outcomeMissed || result.Outcome == outcomeFalsePositive {
+			fmt.Fprintf(w, "\n%s: %s (expected %q, got %q at %s)", result.Name, result.Outcome, result.ExpectedID, result.ActualID, result.Confidence)
+		}
+	}
+	fmt.Fprintln(w)
+}
+
+func loadBaseline(path string) (*report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline: %w", err)
+	}
+
+	var baseline report
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline: %w", err)
+	}
+	return &baseline, nil
+}
+
+func (r *report) save(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal report: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write baseline: %w", err)
+	}
+	return nil
+}
+
+func (r *report) diff(w io.Writer, baseline *report) (regressed bool) {
+	fmt.Fprintln(w, "\nagainst baseline:")
+	fmt.Fprintf(w, "precision: %.3f -> %.3f (%+.3f)\n", baseline.Precision, r.Precision, r.Precision-baseline.Precision)
+	fmt.Fprintf(w, "recall:    %.3f -> %.3f (%+.3f)\n", baseline.Recall, r.Recall, r.Recall-baseline.Recall)
+
+	previous := make(map[string]caseResult, len(baseline.Cases))
+	for _, result := range baseline.Cases {
+		previous[result.Name] = result
+	}
+	for _, result := range r.Cases {
+		before, ok := previous[result.Name]
+		if !ok {
+			fmt.Fprintf(w, "  new case %s: %s at %s\n", result.Name, result.Outcome, result.Confidence)
+			continue
+		}
+		if before.Outcome != result.Outcome || before.Confidence != result.Confidence {
+			fmt.Fprintf(w, "  %s: %s at %s -> %s at %s\n", result.Name, before.Outcome, before.Confidence, result.Outcome, result.Confidence)
+		}
+	}
+
+	return r.Precision < baseline.Precision || r.Recall < baseline.Recall
+}
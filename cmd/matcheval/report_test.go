@@ -0,0 +1,166 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/domain"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		expected string
+		actual   string
+		want     outcome
+	}{
+		{"", "", outcomeTrueNegative},
+		{"", "yt-1", outcomeFalsePositive},
+		{"yt-1", "", outcomeMissed},
+		{"yt-1", "yt-1", outcomeCorrect},
+		{"yt-1", "yt-2", outcomeWrong},
+	}
+
+	for _, tt := range tests {
+		if got := classify(tt.expected, tt.actual); got != tt.want {
+			t.Errorf("classify(%q, %q) = %s, want %s", tt.expected, tt.actual, got, tt.want)
+		}
+	}
+}
+
+func TestNewReport(t *testing.T) {
+	tests := []struct {
+		name          string
+		results       []caseResult
+		wantPrecision float64
+		wantRecall    float64
+	}{
+		{
+			name:    "empty",
+			results: nil,
+		},
+		{
+			name: "all correct",
+			results: []caseResult{
+				{Name: "a", ExpectedID: "yt-1", ActualID: "yt-1", Confidence: domain.MatchConfidenceHigh, Outcome: outcomeCorrect},
+				{Name: "b", Confidence: domain.MatchConfidenceNone, Outcome: outcomeTrueNegative},
+			},
+			wantPrecision: 1,
+			wantRecall:    1,
+		},
+		{
+			name: "mixed",
+			results: []caseResult{
+				{Name: "a", ExpectedID: "yt-1", ActualID: "yt-1", Confidence: domain.MatchConfidenceHigh, Outcome: outcomeCorrect},
+				{Name: "b", ExpectedID: "yt-2", ActualID: "yt-3", Confidence: domain.MatchConfidenceLow, Outcome: outcomeWrong},
+				{Name: "c", ExpectedID: "yt-4", Confidence: domain.MatchConfidenceNone, Outcome: outcomeMissed},
+				{Name: "d", ActualID: "yt-5", Confidence: domain.MatchConfidenceLow, Outcome: outcomeFalsePositive},
+			},
+			wantPrecision: 1.0 / 3,
+			wantRecall:    1.0 / 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newReport(tt.results)
+			if r.Precision != tt.wantPrecision || r.Recall != tt.wantRecall {
+				t.Errorf("precision/recall = %.3f/%.3f, want %.3f/%.3f", r.Precision, r.Recall, tt.wantPrecision, tt.wantRecall)
+			}
+
+			total := 0
+			for _, byOutcome := range r.Confusion {
+				for _, count := range byOutcome {
+					total += count
+				}
+			}
+			if total != len(tt.results) {
+				t.Errorf("confusion matrix counts %d cases, want %d", total, len(tt.results))
+			}
+		})
+	}
+}
+
+func TestReport_Print(t *testing.T) {
+	r := newReport([]caseResult{
+		{Name: "correct", ExpectedID: "yt-1", ActualID: "yt-1", Confidence: domain.MatchConfidenceHigh, Outcome: outcomeCorrect},
+		{Name: "wrong", ExpectedID: "yt-2", ActualID: "yt-3", Confidence: domain.MatchConfidenceLow, Outcome: outcomeWrong},
+	})
+
+	var out bytes.Buffer
+	r.print(&out)
+
+	for _, want := range []string{"cases:     2", "precision: 0.500", "recall:    0.500", `wrong: wrong (expected "yt-2", got "yt-3" at LOW)`} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out.String())
+		}
+	}
+	if strings.Contains(out.String(), "correct: correct") {
+		t.Errorf("expected correct cases not to be listed, got:\n%s", out.String())
+	}
+}
+
+func TestReport_Diff(t *testing.T) {
+	baseline := newReport([]caseResult{
+		{Name: "stable", ExpectedID: "yt-1", ActualID: "yt-1", Confidence: domain.MatchConfidenceHigh, Outcome: outcomeCorrect},
+		{Name: "changed", ExpectedID: "yt-2", ActualID: "yt-2", Confidence: domain.MatchConfidenceHigh, Outcome: outcomeCorrect},
+	})
+
+	tests := []struct {
+		name          string
+		results       []caseResult
+		wantRegressed bool
+		wantLines     []string
+		wantAbsent    []string
+	}{
+		{
+			name: "unchanged",
+			results: []caseResult{
+				{Name: "stable", ExpectedID: "yt-1", ActualID: "yt-1", Confidence: domain.MatchConfidenceHigh, Outcome: outcomeCorrect},
+				{Name: "changed", ExpectedID: "yt-2", ActualID: "yt-2", Confidence: domain.MatchConfidenceHigh, Outcome: outcomeCorrect},
+			},
+			wantLines:  []string{"precision: 1.000 -> 1.000 (+0.000)"},
+			wantAbsent: []string{"stable:", "changed:"},
+		},
+		{
+			name: "regression",
+			results: []caseResult{
+				{Name: "stable", ExpectedID: "yt-1", ActualID: "yt-1", Confidence: domain.MatchConfidenceHigh, Outcome: outcomeCorrect},
+				{Name: "changed", ExpectedID: "yt-2", ActualID: "yt-9", Confidence: domain.MatchConfidenceLow, Outcome: outcomeWrong},
+			},
+			wantRegressed: true,
+			wantLines:     []string{"precision: 1.000 -> 0.500 (-0.500)", "changed: correct at HIGH -> wrong at LOW"},
+			wantAbsent:    []string{"stable:"},
+		},
+		{
+			name: "new case",
+			results: []caseResult{
+				{Name: "stable", ExpectedID: "yt-1", ActualID: "yt-1", Confidence: domain.MatchConfidenceHigh, Outcome: outcomeCorrect},
+				{Name: "changed", ExpectedID: "yt-2", ActualID: "yt-2", Confidence: domain.MatchConfidenceHigh, Outcome: outcomeCorrect},
+				{Name: "added", Confidence: domain.MatchConfidenceNone, Outcome: outcomeTrueNegative},
+			},
+			wantLines: []string{"new case added: true_negative at NONE"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			regressed := newReport(tt.results).diff(&out, baseline)
+
+			if regressed != tt.wantRegressed {
+				t.Errorf("regressed = %v, want %v", regressed, tt.wantRegressed)
+			}
+			for _, want := range tt.wantLines {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("expected output to contain %q, got:\n%s", want, out.String())
+				}
+			}
+			for _, absent := range tt.wantAbsent {
+				if strings.Contains(out.String(), absent) {
+					t.Errorf("expected output not to contain %q, got:\n%s", absent, out.String())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/application"
+	appconfig "github.com/marcelovmendes/playswap/conversion-worker/internal/config"
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/domain"
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/infrastructure/awsconfig"
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/infrastructure/dynamodb"
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/infrastructure/scoringmodel"
+)
+
+func main() {
+	logsPath := flag.String("logs", "", "JSON Lines export of conversion logs; scans the DynamoDB logs table when empty")
+	outPath := flag.String("out", "scoring_weights.json", "where to write the trained weights")
+	epochs := flag.Int("epochs", 1000, "gradient descent iterations")
+	learningRate := flag.Float64("lr", 0.5, "learning rate")
+	l2 := flag.Float64("l2", 0.001, "L2 regularization strength")
+	flag.Parse()
+
+	ctx := context.Background()
+
+	logs, err := loadLogs(ctx, *logsPath)
+	if err != nil {
+		log.Fatal("failed to load logs: ", err)
+	}
+
+	examples := application.BuildTrainingExamples(logs)
+	log.Printf("built %d training examples from %d logs", len(examples), len(logs))
+
+	model, err := application.TrainScoringModel(examples, application.TrainingConfig{
+		Epochs:       *epochs,
+		LearningRate: *learningRate,
+		L2:           *l2,
+	})
+	if err != nil {
+		log.Fatal("failed to train model: ", err)
+	}
+
+	if err := scoringmodel.Save(*outPath, model); err != nil {
+		log.Fatal("failed to write weights: ", err)
+	}
+
+	for _, feature := range model.Features {
+		fmt.Printf("%-18s %8.4f\n", feature, model.Weights[feature])
+	}
+	fmt.Printf("%-18s %8.4f\n", "bias", model.Bias)
+	log.Printf("weights written to %s", *outPath)
+}
+
+func loadLogs(ctx context.Context, path string) ([]*domain.ConversionLog, error) {
+	if path != "" {
+		return readLogFile(path)
+	}
+
+	cfg := appconfig.Load()
+	awsCfg, err := awsconfig.Load(ctx, cfg.AWS)
+	if err != nil {
+		return nil, err
+	}
+	return dynamodb.NewConversionLogReader(awsCfg, cfg.AWS.DynamoDBLogsTable).ListForTraining(ctx)
+}
+
+func readLogFile(path string) ([]*domain.ConversionLog, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var logs []*domain.ConversionLog
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var l domain.ConversionLog
+		if err := json.Unmarshal(scanner.Bytes(), &l); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		logs = append(logs, &l)
+	}
+	return logs, scanner.Err()
+}
@@ -2,20 +2,23 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
-
-	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
-	"github.com/aws/aws-sdk-go-v2/credentials"
+	"time"
 
 	"github.com/marcelovmendes/playswap/conversion-worker/internal/application"
 	appconfig "github.com/marcelovmendes/playswap/conversion-worker/internal/config"
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/domain"
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/infrastructure/awsconfig"
 	"github.com/marcelovmendes/playswap/conversion-worker/internal/infrastructure/dynamodb"
 	"github.com/marcelovmendes/playswap/conversion-worker/internal/infrastructure/http"
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/infrastructure/musicbrainz"
 	"github.com/marcelovmendes/playswap/conversion-worker/internal/infrastructure/redis"
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/infrastructure/scoringmodel"
 	"github.com/marcelovmendes/playswap/conversion-worker/internal/infrastructure/sqs"
 	"github.com/marcelovmendes/playswap/conversion-worker/internal/metrics"
 )
@@ -44,7 +47,7 @@ func main() {
 	}
 	log.Println("connected to redis")
 
-	awsCfg, err := loadAWSConfig(ctx, cfg.AWS)
+	awsCfg, err := awsconfig.Load(ctx, cfg.AWS)
 	if err != nil {
 		log.Fatal("failed to load AWS config: ", err)
 	}
@@ -53,22 +56,83 @@ func main() {
 	queue := sqs.NewJobQueue(awsCfg, cfg.AWS.SQSQueueURL)
 	statusStore := redis.NewStatusStore(redisClient)
 	sessionStore := redis.NewSessionStore(redisClient)
+	checkpointStore := redis.NewCheckpointStore(redisClient)
 
 	conversionRepo := dynamodb.NewConversionRepository(awsCfg, cfg.AWS.DynamoDBConversionsTable)
 	logRepo := dynamodb.NewConversionLogRepository(awsCfg, cfg.AWS.DynamoDBLogsTable)
+	matchRepo := dynamodb.NewMatchRepository(awsCfg, cfg.AWS.DynamoDBMatchesTable)
+	mappingRepo := dynamodb.NewTrackMappingRepository(awsCfg, cfg.AWS.DynamoDBMappingsTable)
+	channelListRepo := dynamodb.NewChannelListRepository(awsCfg, cfg.AWS.DynamoDBChannelListTable)
 
 	spotifyClient := http.NewSpotifyClient(cfg.Services.Spotify, sessionStore)
-	youtubeClient := http.NewYouTubeClient(cfg.Services.YouTube, sessionStore)
+	youtubeClient := application.NewCoalescingYouTubeClient(http.NewYouTubeClient(cfg.Services.YouTube, sessionStore))
+
+	channelEntries, err := configuredChannelEntries(cfg.ChannelLists)
+	if err != nil {
+		log.Fatal("invalid channel lists: ", err)
+	}
+
+	matcherOpts := []application.MatcherOption{
+		application.WithTrackMappings(mappingRepo),
+		application.WithChannelLists(channelEntries, channelListRepo),
+	}
+	if cfg.MatchCache.Enabled {
+		matcherOpts = append(matcherOpts, application.WithMatchCache(redis.NewMatchCache(redisClient, cfg.MatchCache)))
+	}
+
+	if cfg.Enrichment.MusicBrainzDumpPath != "" {
+		enricher, err := musicbrainz.NewDumpEnricher(cfg.Enrichment.MusicBrainzDumpPath)
+		if err != nil {
+			log.Fatal("failed to load musicbrainz dump: ", err)
+		}
+		matcherOpts = append(matcherOpts, application.WithEnricher(enricher))
+		log.Println("loaded musicbrainz dump")
+	}
+
+	var weights domain.ScoringWeights
+	if cfg.Matcher.WeightsPath != "" {
+		model, err := scoringmodel.Load(cfg.Matcher.WeightsPath)
+		if err != nil {
+			log.Fatal("failed to load scoring weights: ", err)
+		}
+		weights = model.Weights
+		log.Printf("loaded scoring weights trained on %d examples at %s", model.Examples, model.TrainedAt.Format(time.RFC3339))
+	}
+
+	newMatcher := func(strategies []string, weights domain.ScoringWeights) application.Matcher {
+		opts := append([]application.MatcherOption{
+			application.WithStrategies(strategies),
+			application.WithScoringWeights(weights),
+		}, matcherOpts...)
+		return application.NewMatcher(youtubeClient, opts...)
+	}
+
+	if err := application.ValidateStrategies(cfg.Matcher.Strategies); err != nil {
+		log.Fatal("invalid MATCHER_STRATEGIES: ", err)
+	}
+	matcher := newMatcher(cfg.Matcher.Strategies, weights)
+
+	converterOpts := []application.ConverterOption{application.WithCheckpoints(checkpointStore)}
+	if cfg.Experiment.Name != "" {
+		experiment, variantMatchers, err := loadExperiment(cfg.Experiment, cfg.Matcher.Strategies, weights, newMatcher)
+		if err != nil {
+			log.Fatal("failed to load experiment: ", err)
+		}
+		converterOpts = append(converterOpts, application.WithExperiment(experiment, variantMatchers))
+		log.Printf("running experiment %s with %d variants", experiment.Name, len(experiment.Variants))
+	}
 
-	matcher := application.NewMatcher(youtubeClient)
 	converter := application.NewConverter(
 		spotifyClient,
 		youtubeClient,
 		matcher,
 		conversionRepo,
 		logRepo,
+		matchRepo,
+		mappingRepo,
 		statusStore,
 		cfg.Worker,
+		converterOpts...,
 	)
 
 	worker := application.NewWorker(queue, converter, cfg.Worker)
@@ -87,26 +151,73 @@ func main() {
 	log.Println("worker stopped")
 }
 
-func loadAWSConfig(ctx context.Context, awsCfg appconfig.AWSConfig) (aws.Config, error) {
-	opts := []func(*config.LoadOptions) error{
-		config.WithRegion(awsCfg.Region),
+// loadExperiment builds a matcher for every variant that overrides the default strategies or weights.
+func loadExperiment(
+	cfg appconfig.ExperimentConfig,
+	strategies []string,
+	weights domain.ScoringWeights,
+	newMatcher func(strategies []string, weights domain.ScoringWeights) application.Matcher,
+) (*domain.Experiment, map[string]application.Matcher, error) {
+	var variants []domain.Variant
+	for _, spec := range cfg.Variants {
+		variant, err := domain.ParseVariant(spec)
+		if err != nil {
+			return nil, nil, err
+		}
+		variants = append(variants, variant)
 	}
 
-	if awsCfg.Endpoint != "" {
-		opts = append(opts,
-			config.WithEndpointResolverWithOptions(
-				aws.EndpointResolverWithOptionsFunc(func(service, region string, options ...interface{}) (aws.Endpoint, error) {
-					return aws.Endpoint{
-						PartitionID:  "aws",
-						URL:          awsCfg.Endpoint,
-						SigningRegion: region,
-						SigningMethod: "v4",
-					}, nil
-				}),
-			),
-			config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider("test", "test", "")),
-		)
+	experiment, err := domain.NewExperiment(cfg.Name, domain.ExperimentBucket(strings.ToUpper(cfg.BucketBy)), variants)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return config.LoadDefaultConfig(ctx, opts...)
+	matchers := make(map[string]application.Matcher)
+	for _, variant := range experiment.Variants {
+		if err := application.ValidateStrategies(variant.Strategies); err != nil {
+			return nil, nil, fmt.Errorf("variant %s: %w", variant.Name, err)
+		}
+		if len(variant.Strategies) == 0 && variant.ModelPath == "" {
+			continue
+		}
+
+		variantStrategies, variantWeights := strategies, weights
+		if len(variant.Strategies) > 0 {
+			variantStrategies = variant.Strategies
+		}
+		if variant.ModelPath != "" {
+			model, err := scoringmodel.Load(variant.ModelPath)
+			if err != nil {
+				return nil, nil, fmt.Errorf("variant %s: %w", variant.Name, err)
+			}
+			variantWeights = model.Weights
+			log.Printf("variant %s uses scoring weights trained on %d examples", variant.Name, model.Examples)
+		}
+		matchers[variant.Name] = newMatcher(variantStrategies, variantWeights)
+	}
+	return experiment, matchers, nil
+}
+
+func configuredChannelEntries(cfg appconfig.ChannelListConfig) ([]*domain.ChannelListEntry, error) {
+	lists := []struct {
+		kind   domain.ChannelListKind
+		target domain.ChannelListTarget
+		values []string
+	}{
+		{domain.ChannelListAllow, domain.ChannelListTargetChannel, cfg.TrustedChannels},
+		{domain.ChannelListBlock, domain.ChannelListTargetChannel, cfg.BlockedChannels},
+		{domain.ChannelListBlock, domain.ChannelListTargetVideo, cfg.BlockedVideos},
+	}
+
+	var entries []*domain.ChannelListEntry
+	for _, list := range lists {
+		for _, value := range list.values {
+			entry, err := domain.NewChannelListEntry(domain.ChannelListScopeGlobal, list.kind, list.target, value, "config")
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
 }
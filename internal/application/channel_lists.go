@@ -0,0 +1,40 @@
+package application
+
+import (
+	"context"
+	"log"
+
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/domain"
+)
+
+type ChannelListRepository interface {
+	ListByScope(ctx context.Context, scope string) ([]*domain.ChannelListEntry, error)
+	Put(ctx context.Context, entry *domain.ChannelListEntry) error
+	Delete(ctx context.Context, entry *domain.ChannelListEntry) error
+}
+
+// loadChannelLists merges the configured entries with the global and per-user entries stored in
+// the repository. Lookup failures are logged and the configured entries still apply.
+func (m *matcher) loadChannelLists(ctx context.Context, userID string) *domain.ChannelLists {
+	if len(m.channelEntries) == 0 && m.channelLists == nil {
+		return nil
+	}
+
+	entries := append([]*domain.ChannelListEntry(nil), m.channelEntries...)
+	if m.channelLists != nil {
+		scopes := []string{domain.ChannelListScopeGlobal}
+		if userID != "" {
+			scopes = append(scopes, userID)
+		}
+		for _, scope := range scopes {
+			stored, err := m.channelLists.ListByScope(ctx, scope)
+			if err != nil {
+				log.Printf("failed to load channel lists for scope %s: %v", scope, err)
+				continue
+			}
+			entries = append(entries, stored...)
+		}
+	}
+
+	return domain.NewChannelLists(entries)
+}
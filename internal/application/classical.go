@@ -0,0 +1,76 @@
+package application
+
+import (
+	"strings"
+
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/domain"
+)
+
+const classicalMatchMethod = "classical_match"
+
+// classicalConfidence compares parsed work identifiers instead of raw titles, since uploads of the
+// same recording rarely repeat the streaming title verbatim.
+func classicalConfidence(source *domain.Track, work domain.ClassicalWork, target *domain.Track) domain.MatchConfidence {
+	targetWork, ok := domain.ParseClassicalTitle(target.Name)
+	if !ok || !work.SameWork(targetWork) {
+		return domain.MatchConfidenceNone
+	}
+	if work.Movement > 0 && targetWork.Movement > 0 && work.Movement != targetWork.Movement {
+		return domain.MatchConfidenceNone
+	}
+
+	composerMatch := hasComposerMatch(composerNames(source, work), target)
+	sharedCatalog := work.Catalog != "" && work.Catalog == targetWork.Catalog
+	if !composerMatch && !(sharedCatalog && work.CatalogIdentifiesComposer()) {
+		return domain.MatchConfidenceNone
+	}
+
+	if work.Movement == targetWork.Movement && composerMatch {
+		return domain.MatchConfidenceHigh
+	}
+	return domain.MatchConfidenceMedium
+}
+
+func composerNames(source *domain.Track, work domain.ClassicalWork) []string {
+	if work.Composer != "" {
+		return []string{work.Composer}
+	}
+	return matchArtists(source)
+}
+
+func hasComposerMatch(names []string, target *domain.Track) bool {
+	haystack := domain.NormalizeText(target.Name + " " + target.Artist)
+	for _, name := range names {
+		if surname := composerSurname(name); surname != "" && strings.Contains(haystack, surname) {
+			return true
+		}
+	}
+	return false
+}
+
+func composerSurname(name string) string {
+	words := strings.Fields(domain.NormalizeText(name))
+	if len(words) == 0 {
+		return ""
+	}
+	return words[len(words)-1]
+}
+
+// matchArtists returns the names to look for in a candidate. Compilations credit "Various Artists"
+// on the album, so the track-level artists are used instead.
+func matchArtists(track *domain.Track) []string {
+	if domain.IsCompilationArtist(track.Artist) {
+		return track.Artists
+	}
+	return []string{track.Artist}
+}
+
+func searchArtist(track *domain.Track) string {
+	if !domain.IsCompilationArtist(track.Artist) {
+		return track.Artist
+	}
+	if len(track.Artists) > 0 {
+		return track.Artists[0]
+	}
+	return ""
+}
@@ -0,0 +1,90 @@
+package application
+
+import (
+	"context"
+	"sync"
+
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/domain"
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/infrastructure/http"
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/metrics"
+)
+
+type flight[T any] struct {
+	done chan struct{}
+	val  T
+	err  error
+}
+
+type flightGroup[T any] struct {
+	mu      sync.Mutex
+	flights map[string]*flight[T]
+}
+
+func (g *flightGroup[T]) do(ctx context.Context, key string, fn func() (T, error)) (val T, shared bool, err error) {
+	g.mu.Lock()
+	if f, ok := g.flights[key]; ok {
+		g.mu.Unlock()
+
+		select {
+		case <-f.done:
+		case <-ctx.Done():
+			return val, false, ctx.Err()
+		}
+		if f.err == nil {
+			return f.val, true, nil
+		}
+
+		val, err = fn()
+		return val, false, err
+	}
+
+	f := &flight[T]{done: make(chan struct{})}
+	if g.flights == nil {
+		g.flights = make(map[string]*flight[T])
+	}
+	g.flights[key] = f
+	g.mu.Unlock()
+
+	defer func() {
+		g.mu.Lock()
+		delete(g.flights, key)
+		g.mu.Unlock()
+		close(f.done)
+	}()
+
+	f.val, f.err = fn()
+	return f.val, false, f.err
+}
+
+type coalescingYouTubeClient struct {
+	http.YouTubeClient
+	isrcLookups flightGroup[*domain.Track]
+	searches    flightGroup[[]*domain.Track]
+}
+
+// NewCoalescingYouTubeClient shares identical in-flight searches between all callers of the returned
+// client. Create it once per process so that concurrent jobs, not only tracks of one job, share calls.
+func NewCoalescingYouTubeClient(client http.YouTubeClient) http.YouTubeClient {
+	return &coalescingYouTubeClient{YouTubeClient: client}
+}
+
+func (c *coalescingYouTubeClient) SearchByISRC(ctx context.Context, isrc, market, sessionID string) (*domain.Track, error) {
+	track, shared, err := c.isrcLookups.do(ctx, marketKey(isrc, market), func() (*domain.Track, error) {
+		return c.YouTubeClient.SearchByISRC(ctx, isrc, market, sessionID)
+	})
+	if shared {
+		metrics.MatchSearchesCoalesced.WithLabelValues("isrc").Inc()
+	}
+	return track, err
+}
+
+func (c *coalescingYouTubeClient) SearchTrack(ctx context.Context, track, artist, market, sessionID string) ([]*domain.Track, error) {
+	key := marketKey(domain.NormalizeText(track)+"|"+domain.NormalizeText(artist), market)
+	tracks, shared, err := c.searches.do(ctx, key, func() ([]*domain.Track, error) {
+		return c.YouTubeClient.SearchTrack(ctx, track, artist, market, sessionID)
+	})
+	if shared {
+		metrics.MatchSearchesCoalesced.WithLabelValues("search").Inc()
+	}
+	return tracks, err
+}
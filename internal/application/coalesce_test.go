@@ -0,0 +1,115 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/domain"
+)
+
+func TestFlightGroup_SharesInFlightResult(t *testing.T) {
+	var g flightGroup[string]
+	var calls atomic.Int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		g.do(context.Background(), "key", func() (string, error) {
+			calls.Add(1)
+			close(started)
+			<-release
+			return "result", nil
+		})
+	}()
+	<-started
+
+	var val string
+	var shared bool
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		val, shared, _ = g.do(context.Background(), "key", func() (string, error) {
+			calls.Add(1)
+			return "own call", nil
+		})
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if calls.Load() != 1 {
+		t.Errorf("expected 1 upstream call, got %d", calls.Load())
+	}
+	if val != "result" || !shared {
+		t.Errorf("do() = (%q, %v), want shared result", val, shared)
+	}
+}
+
+func TestFlightGroup_RetriesAfterSharedError(t *testing.T) {
+	var g flightGroup[string]
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	go g.do(context.Background(), "key", func() (string, error) {
+		close(started)
+		<-release
+		return "", errors.New("token expired")
+	})
+	<-started
+
+	done := make(chan struct{})
+	var val string
+	var err error
+	go func() {
+		defer close(done)
+		val, _, err = g.do(context.Background(), "key", func() (string, error) {
+			return "own call", nil
+		})
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	<-done
+
+	if err != nil || val != "own call" {
+		t.Errorf("do() = (%q, %v), want own call result", val, err)
+	}
+}
+
+func TestCoalescingYouTubeClient_SharesSearchesAcrossConversions(t *testing.T) {
+	_, youtubeClient := resumeFixture()
+	youtubeClient.searchDelay = func(string) time.Duration { return 50 * time.Millisecond }
+	playlist, _ := domain.NewPlaylist("Queen Hits", domain.PlatformSpotify, "playlist-1")
+	playlist.AddTracks([]*domain.Track{mustSourceTrack("Bohemian Rhapsody", "Queen", "sp1")})
+	client := NewCoalescingYouTubeClient(youtubeClient)
+
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i, user := range []string{"user-1", "user-2"} {
+		f := newConverterFixtureWithClient(playlist, youtubeClient, client)
+		job := domain.NewConversionJob(user, domain.PlatformSpotify, domain.PlatformYouTube, "playlist-1", "Converted")
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = f.converter.Convert(context.Background(), job)
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("Convert() error: %v", err)
+		}
+	}
+	if calls := youtubeClient.searchCalls.Load(); calls != 1 {
+		t.Errorf("expected 1 upstream search shared by both conversions, got %d", calls)
+	}
+}
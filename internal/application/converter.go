@@ -2,6 +2,7 @@ package application
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"time"
@@ -13,9 +14,15 @@ import (
 	"github.com/marcelovmendes/playswap/conversion-worker/internal/metrics"
 )
 
+var (
+	ErrConversionExists  = errors.New("conversion already exists")
+	ErrConversionClaimed = errors.New("conversion changed by another worker")
+)
+
 type ConversionRepository interface {
 	Create(ctx context.Context, c *domain.Conversion) error
 	Update(ctx context.Context, c *domain.Conversion) error
+	Get(ctx context.Context, id string) (*domain.Conversion, error)
 }
 
 type ConversionLogRepository interface {
@@ -23,8 +30,20 @@ type ConversionLogRepository interface {
 	CreateBatch(ctx context.Context, logs []*domain.ConversionLog) error
 }
 
+type MatchRepository interface {
+	SaveBatch(ctx context.Context, conversionID string, matches []*domain.TrackMatch) error
+	ListByConversion(ctx context.Context, conversionID string) ([]*domain.TrackMatch, error)
+}
+
+type TrackMappingRepository interface {
+	FindBest(ctx context.Context, sourcePlatform domain.Platform, sourceID string, targetPlatform domain.Platform) (*domain.TrackMapping, error)
+	RecordVote(ctx context.Context, mapping *domain.TrackMapping, vote domain.MappingVote) error
+}
+
 type Converter interface {
 	Convert(ctx context.Context, job *domain.ConversionJob) error
+	ApplyReview(ctx context.Context, job *domain.ConversionJob) error
+	SwapTracks(ctx context.Context, job *domain.ConversionJob) error
 }
 
 type converter struct {
@@ -33,22 +52,60 @@ type converter struct {
 	matcher        Matcher
 	conversionRepo ConversionRepository
 	logRepo        ConversionLogRepository
+	matchRepo      MatchRepository
+	mappingRepo    TrackMappingRepository
 	statusStore    redis.StatusStore
 	config         config.WorkerConfig
+	minConfidence  domain.MatchConfidence
+	experiment     *domain.Experiment
+	variants       map[string]Matcher
+	checkpoints    redis.CheckpointStore
+}
+
+type ConverterOption func(*converter)
+
+// WithExperiment assigns each conversion a variant and matches it with that variant's matcher.
+// Variants without a matcher use the default one.
+func WithExperiment(experiment *domain.Experiment, matchers map[string]Matcher) ConverterOption {
+	return func(c *converter) {
+		c.experiment = experiment
+		c.variants = matchers
+	}
+}
+
+// WithCheckpoints saves every match as soon as it finishes, so a redelivered job
+// only matches the tracks that were not done before the worker stopped.
+func WithCheckpoints(store redis.CheckpointStore) ConverterOption {
+	return func(c *converter) {
+		c.checkpoints = store
+	}
 }
 
 func NewConverter(spotifyClient http.SpotifyClient, youtubeClient http.YouTubeClient, matcher Matcher, conversionRepo ConversionRepository,
-	logRepo ConversionLogRepository, statusStore redis.StatusStore, cfg config.WorkerConfig) Converter {
+	logRepo ConversionLogRepository, matchRepo MatchRepository, mappingRepo TrackMappingRepository, statusStore redis.StatusStore, cfg config.WorkerConfig, opts ...ConverterOption) Converter {
 
-	return &converter{
+	minConfidence, ok := domain.ParseMatchConfidence(cfg.MinMatchConfidence)
+	if !ok || minConfidence == domain.MatchConfidenceNone {
+		log.Printf("invalid minimum match confidence %q, falling back to %s", cfg.MinMatchConfidence, domain.MatchConfidenceLow)
+		minConfidence = domain.MatchConfidenceLow
+	}
+
+	c := &converter{
 		spotifyClient:  spotifyClient,
 		youtubeClient:  youtubeClient,
 		matcher:        matcher,
 		conversionRepo: conversionRepo,
 		logRepo:        logRepo,
+		matchRepo:      matchRepo,
+		mappingRepo:    mappingRepo,
 		statusStore:    statusStore,
 		config:         cfg,
+		minConfidence:  minConfidence,
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
+	return c
 }
 
 func (c *converter) Convert(ctx context.Context, job *domain.ConversionJob) error {
@@ -67,9 +124,48 @@ func (c *converter) Convert(ctx context.Context, job *domain.ConversionJob) erro
 		return fmt.Errorf("failed to create conversion: %w", err)
 	}
 
-	if err := c.conversionRepo.Create(ctx, conversion); err != nil {
-		metrics.JobsFailed.WithLabelValues("persist_conversion").Inc()
-		return fmt.Errorf("failed to persist conversion: %w", err)
+	existing, err := c.conversionRepo.Get(ctx, conversion.ID)
+	if err != nil {
+		metrics.JobsFailed.WithLabelValues("load_conversion").Inc()
+		return fmt.Errorf("failed to load conversion: %w", err)
+	}
+
+	var matcher Matcher
+	switch {
+	case existing == nil:
+		matcher = c.assignVariant(job, conversion)
+		conversion.Claim(c.config.JobTimeout)
+
+		if err := c.conversionRepo.Create(ctx, conversion); err != nil {
+			if errors.Is(err, ErrConversionExists) {
+				// Another worker created the conversion in the meantime and runs the job.
+				return c.dropClaimedJob(conversion.ID)
+			}
+			metrics.JobsFailed.WithLabelValues("persist_conversion").Inc()
+			return fmt.Errorf("failed to persist conversion: %w", err)
+		}
+	case existing.Status == domain.ConversionStatusCompleted:
+		log.Printf("conversion %s already completed, acknowledging redelivered job", existing.ID)
+		metrics.JobsSkipped.WithLabelValues("completed").Inc()
+		return nil
+	case existing.Status == domain.ConversionStatusAwaitingReview:
+		log.Printf("conversion %s already awaiting review, ignoring redelivered job", existing.ID)
+		metrics.JobsSkipped.WithLabelValues("awaiting_review").Inc()
+		return nil
+	default:
+		claimed, err := c.claim(ctx, existing)
+		if err != nil {
+			metrics.JobsFailed.WithLabelValues("claim_conversion").Inc()
+			return err
+		}
+		if !claimed {
+			return c.dropClaimedJob(existing.ID)
+		}
+
+		log.Printf("resuming conversion %s from %s", existing.ID, existing.Status)
+		metrics.JobsResumed.WithLabelValues(string(existing.Status)).Inc()
+		conversion = existing
+		matcher = c.variantMatcher(conversion.Variant)
 	}
 
 	defer func() {
@@ -81,10 +177,19 @@ func (c *converter) Convert(ctx context.Context, job *domain.ConversionJob) erro
 		}
 	}()
 
+	// Matches are stored before the playlist is created, so only that stage has to run again.
+	if conversion.Status == domain.ConversionStatusCreating || conversion.TargetPlaylistID != "" {
+		matches, err := c.matchRepo.ListByConversion(ctx, conversion.ID)
+		if err != nil {
+			return c.handleError(ctx, conversion, "failed to load matches", err)
+		}
+		return c.createTargetPlaylist(ctx, conversion, matches)
+	}
+
 	conversion.StartFetching()
 	c.updateStatus(ctx, conversion)
 
-	playlist, err := c.spotifyClient.GetPlaylistTracks(ctx, job.SourcePlaylistID, job.UserID)
+	playlist, err := c.spotifyClient.GetPlaylistTracks(ctx, job.SourcePlaylistID, job.Market, job.UserID)
 	if err != nil {
 		if logErr := c.logRepo.Create(ctx, domain.NewFetchPlaylistLog(conversion.ID, domain.LogStatusFailed, err.Error())); logErr != nil {
 			log.Printf("failed to save fetch playlist failure log: %v", logErr)
@@ -103,23 +208,60 @@ func (c *converter) Convert(ctx context.Context, job *domain.ConversionJob) erro
 		tracks = filterTracks(tracks, job.SelectedTrackIDs)
 	}
 
+	opts := job.MatchOptions
+	if opts.MinConfidence == "" {
+		opts.MinConfidence = c.minConfidence
+	}
+	opts.Market = job.Market
+
+	unique, duplicates := domain.FindDuplicates(tracks)
+	if opts.Duplicates == domain.DuplicatePolicyDrop {
+		tracks = unique
+	}
+
+	restored := c.loadCheckpoint(ctx, conversion.ID)
+	pending := make([]*domain.Track, 0, len(unique))
+	var restoredMatches []*domain.TrackMatch
+	for _, track := range unique {
+		if match, ok := restored[track.PlatformID]; ok {
+			restoredMatches = append(restoredMatches, match)
+			continue
+		}
+		pending = append(pending, track)
+	}
+	if len(restoredMatches) > 0 {
+		log.Printf("conversion %s restored %d matched tracks from checkpoint", conversion.ID, len(restoredMatches))
+		metrics.CheckpointedTracks.Add(float64(len(restoredMatches)))
+	}
+	restoredProcessed, restoredMatched, restoredReview, restoredFailed := countMatches(restoredMatches)
+
 	conversion.StartMatching(len(tracks), playlist.Name)
+	conversion.UpdateProgress(restoredProcessed, restoredMatched, restoredReview, restoredFailed)
 	c.updateStatus(ctx, conversion)
 
-	matches := c.matcher.MatchTracks(ctx, tracks, job.UserID, c.config.Concurrency, func(processed, matched, failed int) {
-		conversion.UpdateProgress(processed, matched, failed)
+	pendingMatches := matcher.MatchTracks(ctx, pending, job.UserID, opts, c.config.Concurrency, func(match *domain.TrackMatch, processed, matched, needsReview, failed int) {
+		c.saveCheckpoint(ctx, conversion.ID, match)
+		conversion.UpdateProgress(restoredProcessed+processed, restoredMatched+matched, restoredReview+needsReview, restoredFailed+failed)
 		c.updateStatus(ctx, conversion)
 	})
+	uniqueMatches := mergeCheckpoint(unique, restored, pendingMatches)
 
-	var logs []*domain.ConversionLog
-	var matchedVideoIDs []string
+	matches := expandDuplicates(tracks, uniqueMatches, duplicates)
+	if len(duplicates) > 0 {
+		processed, matched, needsReview, failed := countMatches(matches)
+		conversion.UpdateProgress(processed, matched, needsReview, failed)
+	}
+
+	logs := duplicateLogs(conversion.ID, duplicates, opts.Duplicates)
 
 	for _, match := range matches {
-		if match.Confidence != domain.MatchConfidenceNone {
-			logs = append(logs, domain.NewMatchTrackLog(conversion.ID, match.SourceTrack, match.TargetTrack, domain.LogStatusSuccess))
-			matchedVideoIDs = append(matchedVideoIDs, match.TargetTrack.PlatformID)
-		} else {
-			logs = append(logs, domain.NewMatchTrackErrorLog(conversion.ID, match.SourceTrack, match.Error))
+		switch {
+		case match.NeedsReview:
+			logs = append(logs, domain.NewMatchTrackReviewLog(conversion.ID, match.SourceTrack, match.TargetTrack, match.Error).WithMatchDetails(match).WithVariant(conversion.Variant))
+		case match.IsMatched():
+			logs = append(logs, domain.NewMatchTrackLog(conversion.ID, match.SourceTrack, match.TargetTrack, domain.LogStatusSuccess).WithMatchDetails(match).WithVariant(conversion.Variant))
+		default:
+			logs = append(logs, domain.NewMatchTrackErrorLog(conversion.ID, match.SourceTrack, match.Error).WithMatchDetails(match).WithVariant(conversion.Variant))
 		}
 	}
 
@@ -127,54 +269,355 @@ func (c *converter) Convert(ctx context.Context, job *domain.ConversionJob) erro
 		log.Printf("failed to save match logs: %v", err)
 	}
 
+	c.recordMappings(ctx, matches)
+	observeISRCMetrics(matches)
+	observeVariantMetrics(conversion, matches)
+
+	if opts.RequireReview && conversion.ReviewTracks > 0 {
+		return c.awaitReview(ctx, conversion, matches)
+	}
+
+	return c.createTargetPlaylist(ctx, conversion, matches)
+}
+
+func (c *converter) ApplyReview(ctx context.Context, job *domain.ConversionJob) error {
+	metrics.ReviewDecisionsReceived.Inc()
+
+	conversion, err := c.conversionRepo.Get(ctx, job.JobID)
+	if err != nil {
+		return fmt.Errorf("failed to load conversion: %w", err)
+	}
+	if conversion == nil {
+		return fmt.Errorf("conversion %s not found", job.JobID)
+	}
+	if conversion.UserID != job.UserID {
+		return fmt.Errorf("conversion %s does not belong to user %s", conversion.ID, job.UserID)
+	}
+	if conversion.Status.IsTerminal() {
+		log.Printf("conversion %s already %s, ignoring review decision", conversion.ID, conversion.Status)
+		return nil
+	}
+	if conversion.IsClaimed(time.Now()) {
+		return c.dropClaimedJob(conversion.ID)
+	}
+	if conversion.Status != domain.ConversionStatusAwaitingReview {
+		return fmt.Errorf("conversion %s is not awaiting review (status %s)", conversion.ID, conversion.Status)
+	}
+
+	// Duplicate review messages race for the same claim, so only one of them creates the playlist.
+	claimed, err := c.claim(ctx, conversion)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return c.dropClaimedJob(conversion.ID)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("panic during review of conversion %s: %v", conversion.ID, r)
+			metrics.JobsFailed.WithLabelValues("panic").Inc()
+			conversion.Fail(fmt.Sprintf("internal error: %v", r))
+			c.saveState(ctx, conversion)
+		}
+	}()
+
+	matches, err := c.matchRepo.ListByConversion(ctx, conversion.ID)
+	if err != nil {
+		return c.handleError(ctx, conversion, "failed to load review candidates", err)
+	}
+
+	logs := applyReviewDecisions(conversion.ID, matches, job.ReviewDecisions)
+	if err := c.logRepo.CreateBatch(ctx, logs); err != nil {
+		log.Printf("failed to save review logs: %v", err)
+	}
+
+	c.recordCorrections(ctx, matches, job.ReviewDecisions)
+
+	processed, matched, needsReview, failed := countMatches(matches)
+	conversion.UpdateProgress(processed, matched, needsReview, failed)
+
+	return c.createTargetPlaylist(ctx, conversion, matches)
+}
+
+func (c *converter) SwapTracks(ctx context.Context, job *domain.ConversionJob) error {
+	conversion, err := c.conversionRepo.Get(ctx, job.JobID)
+	if err != nil {
+		return fmt.Errorf("failed to load conversion: %w", err)
+	}
+	if conversion == nil {
+		return fmt.Errorf("conversion %s not found", job.JobID)
+	}
+	if conversion.UserID != job.UserID {
+		return fmt.Errorf("conversion %s does not belong to user %s", conversion.ID, job.UserID)
+	}
+	if conversion.Status != domain.ConversionStatusCompleted || conversion.TargetPlaylistID == "" {
+		return fmt.Errorf("conversion %s has no target playlist to update (status %s)", conversion.ID, conversion.Status)
+	}
+
+	matches, err := c.matchRepo.ListByConversion(ctx, conversion.ID)
+	if err != nil {
+		return fmt.Errorf("failed to load matches: %w", err)
+	}
+
+	bySource := make(map[string]*domain.TrackMatch, len(matches))
+	for _, match := range matches {
+		bySource[match.SourceTrack.PlatformID] = match
+	}
+
+	var logs []*domain.ConversionLog
+	swapped := false
+	for _, swap := range job.Swaps {
+		match := bySource[swap.SourceTrackID]
+		if match == nil {
+			metrics.TrackSwaps.WithLabelValues("not_found").Inc()
+			logs = append(logs, domain.NewSwapTrackLog(conversion.ID, nil, nil, nil, domain.LogStatusFailed,
+				fmt.Sprintf("source track %s not found", swap.SourceTrackID)))
+			continue
+		}
+
+		previous := match.TargetTrack
+		if !match.IsMatched() {
+			previous = nil
+		}
+
+		target := match.FindCandidate(swap.TargetTrackID)
+		if target == nil {
+			metrics.TrackSwaps.WithLabelValues("not_found").Inc()
+			logs = append(logs, domain.NewSwapTrackLog(conversion.ID, match.SourceTrack, previous, nil, domain.LogStatusFailed,
+				fmt.Sprintf("candidate %s not found", swap.TargetTrackID)))
+			continue
+		}
+		if previous != nil && previous.PlatformID == target.PlatformID {
+			metrics.TrackSwaps.WithLabelValues("skipped").Inc()
+			logs = append(logs, domain.NewSwapTrackLog(conversion.ID, match.SourceTrack, previous, target, domain.LogStatusSkipped, ""))
+			continue
+		}
+
+		if err := c.replaceVideo(ctx, conversion, previous, target); err != nil {
+			metrics.TrackSwaps.WithLabelValues("failed").Inc()
+			logs = append(logs, domain.NewSwapTrackLog(conversion.ID, match.SourceTrack, previous, target, domain.LogStatusFailed, err.Error()))
+			continue
+		}
+
+		match.ApplySwap(target)
+		c.recordVote(ctx, match, domain.MappingVoteCorrection)
+		metrics.TrackSwaps.WithLabelValues("success").Inc()
+		logs = append(logs, domain.NewSwapTrackLog(conversion.ID, match.SourceTrack, previous, target, domain.LogStatusSuccess, ""))
+		swapped = true
+	}
+
+	if err := c.logRepo.CreateBatch(ctx, logs); err != nil {
+		log.Printf("failed to save swap logs: %v", err)
+	}
+
+	if !swapped {
+		return nil
+	}
+
+	if err := c.matchRepo.SaveBatch(ctx, conversion.ID, matches); err != nil {
+		log.Printf("failed to save swapped matches for conversion %s: %v", conversion.ID, err)
+	}
+
+	processed, matched, needsReview, failed := countMatches(matches)
+	conversion.UpdateProgress(processed, matched, needsReview, failed)
+	c.saveState(ctx, conversion)
+
+	return nil
+}
+
+func (c *converter) replaceVideo(ctx context.Context, conversion *domain.Conversion, previous, target *domain.Track) error {
+	if previous == nil {
+		results, err := c.youtubeClient.AddVideosToPlaylist(ctx, conversion.TargetPlaylistID, []string{target.PlatformID}, conversion.UserID)
+		if err != nil {
+			return err
+		}
+		for _, result := range results {
+			if result.Failed() {
+				return fmt.Errorf("failed to add video %s: %s", result.VideoID, result.Error)
+			}
+		}
+		return nil
+	}
+	return c.youtubeClient.ReplaceVideoInPlaylist(ctx, conversion.TargetPlaylistID, previous.PlatformID, target.PlatformID, conversion.UserID)
+}
+
+func (c *converter) awaitReview(ctx context.Context, conversion *domain.Conversion, matches []*domain.TrackMatch) error {
+	if err := c.matchRepo.SaveBatch(ctx, conversion.ID, matches); err != nil {
+		return c.handleError(ctx, conversion, "failed to save review candidates", err)
+	}
+	c.clearCheckpoint(ctx, conversion.ID)
+
+	conversion.AwaitReview()
+	c.saveState(ctx, conversion)
+	metrics.JobsAwaitingReview.Inc()
+
+	log.Printf("conversion %s awaiting review: %d tracks need review", conversion.ID, conversion.ReviewTracks)
+	return nil
+}
+
+func (c *converter) createTargetPlaylist(ctx context.Context, conversion *domain.Conversion, matches []*domain.TrackMatch) error {
+	var matchedVideoIDs []string
+	for _, match := range matches {
+		if match.IsMatched() {
+			matchedVideoIDs = append(matchedVideoIDs, match.TargetTrack.PlatformID)
+		}
+	}
+
 	if len(matchedVideoIDs) == 0 {
 		return c.handleError(ctx, conversion, "no tracks matched", nil)
 	}
 
+	if err := c.matchRepo.SaveBatch(ctx, conversion.ID, matches); err != nil {
+		log.Printf("failed to save matches for conversion %s: %v", conversion.ID, err)
+	} else {
+		c.clearCheckpoint(ctx, conversion.ID)
+	}
+
 	conversion.StartCreating()
-	c.updateStatus(ctx, conversion)
+	c.saveState(ctx, conversion)
 
-	description := fmt.Sprintf("Converted from Spotify playlist: %s", playlist.Name)
-	playlistID, playlistURL, err := c.youtubeClient.CreatePlaylist(ctx, job.TargetPlaylistName, description, job.UserID)
-	log.Printf("[DEBUG] PlaylistURL and PlaylistId: %s  %s", playlistURL, playlistID)
-	if err != nil {
-		if logErr := c.logRepo.Create(ctx, domain.NewCreatePlaylistLog(conversion.ID, domain.LogStatusFailed, err.Error())); logErr != nil {
-			log.Printf("failed to save create playlist failure log: %v", logErr)
+	playlistID, playlistURL := conversion.TargetPlaylistID, conversion.TargetPlaylistURL
+	if playlistID != "" {
+		log.Printf("conversion %s reusing target playlist %s", conversion.ID, playlistID)
+	} else {
+		var err error
+		description := fmt.Sprintf("Converted from Spotify playlist: %s", conversion.SourcePlaylistName)
+		playlistID, playlistURL, err = c.youtubeClient.CreatePlaylist(ctx, conversion.TargetPlaylistName, description, conversion.UserID)
+		log.Printf("[DEBUG] PlaylistURL and PlaylistId: %s  %s", playlistURL, playlistID)
+		if err != nil {
+			if logErr := c.logRepo.Create(ctx, domain.NewCreatePlaylistLog(conversion.ID, domain.LogStatusFailed, err.Error())); logErr != nil {
+				log.Printf("failed to save create playlist failure log: %v", logErr)
+			}
+			return c.handleError(ctx, conversion, "failed to create playlist", err)
+		}
+
+		conversion.AttachTargetPlaylist(playlistID, playlistURL)
+		if err := c.persistState(ctx, conversion); err != nil {
+			// Without the stored ID a retry would create another playlist, so nothing is added to this one.
+			return c.abortOnSaveError(ctx, conversion, "failed to record target playlist", err)
+		}
+
+		if err := c.logRepo.Create(ctx, domain.NewCreatePlaylistLog(conversion.ID, domain.LogStatusSuccess, "")); err != nil {
+			log.Printf("failed to save create playlist log: %v", err)
 		}
-		return c.handleError(ctx, conversion, "failed to create playlist", err)
 	}
 
-	if err := c.logRepo.Create(ctx, domain.NewCreatePlaylistLog(conversion.ID, domain.LogStatusSuccess, "")); err != nil {
-		log.Printf("failed to save create playlist log: %v", err)
+	pendingVideoIDs := conversion.PendingInserts(matchedVideoIDs)
+	if skipped := len(matchedVideoIDs) - len(pendingVideoIDs); skipped > 0 {
+		log.Printf("conversion %s skipping %d videos already added to playlist %s", conversion.ID, skipped, playlistID)
 	}
 
-	if err := c.youtubeClient.AddVideosToPlaylist(ctx, playlistID, matchedVideoIDs, job.UserID); err != nil {
+	results, err := c.youtubeClient.AddVideosToPlaylist(ctx, playlistID, pendingVideoIDs, conversion.UserID)
+	c.recordInsertedVideos(ctx, conversion, results)
+	if err != nil {
 		if logErr := c.logRepo.Create(ctx, domain.NewAddTrackLog(conversion.ID, nil, domain.LogStatusFailed, err.Error())); logErr != nil {
 			log.Printf("failed to save add track failure log: %v", logErr)
 		}
 		return c.handleError(ctx, conversion, "failed to add videos to playlist", err)
 	}
 
+	insertErrors := make(map[string]string)
+	for _, result := range results {
+		if result.Failed() {
+			insertErrors[result.VideoID] = result.Error
+		}
+	}
+
+	pending := make(map[string]int, len(pendingVideoIDs))
+	for _, videoID := range pendingVideoIDs {
+		pending[videoID]++
+	}
+
 	var addLogs []*domain.ConversionLog
+	failedInserts := 0
 	for _, match := range matches {
-		if match.Confidence != domain.MatchConfidenceNone {
-			addLogs = append(addLogs, domain.NewAddTrackLog(conversion.ID, match.TargetTrack, domain.LogStatusSuccess, ""))
+		if !match.IsMatched() || pending[match.TargetTrack.PlatformID] == 0 {
+			continue
 		}
+		pending[match.TargetTrack.PlatformID]--
+		if insertErr, ok := insertErrors[match.TargetTrack.PlatformID]; ok {
+			failedInserts++
+			metrics.PlaylistInserts.WithLabelValues("failed").Inc()
+			addLogs = append(addLogs, domain.NewAddTrackLog(conversion.ID, match.TargetTrack, domain.LogStatusFailed, insertErr))
+			continue
+		}
+		metrics.PlaylistInserts.WithLabelValues("added").Inc()
+		addLogs = append(addLogs, domain.NewAddTrackLog(conversion.ID, match.TargetTrack, domain.LogStatusSuccess, ""))
 	}
 	if err := c.logRepo.CreateBatch(ctx, addLogs); err != nil {
 		log.Printf("failed to save add track logs: %v", err)
 	}
 
+	if len(addLogs) > 0 && failedInserts == len(addLogs) {
+		return c.handleError(ctx, conversion, "failed to add videos to playlist", fmt.Errorf("all %d videos were rejected", failedInserts))
+	}
+	conversion.RecordFailedInserts(failedInserts)
+
 	conversion.Complete(playlistID, playlistURL)
 	c.saveState(ctx, conversion)
 	metrics.JobsCompleted.Inc()
+	if conversion.Variant != "" {
+		metrics.ExperimentJobs.WithLabelValues(conversion.Experiment, conversion.Variant, string(domain.ConversionStatusCompleted)).Inc()
+	}
 
-	log.Printf("conversion %s completed: %d/%d tracks matched, playlist: %s",
-		conversion.ID, conversion.MatchedTracks, conversion.TotalTracks, playlistURL)
+	log.Printf("conversion %s completed: %d/%d tracks matched, %d inserts failed, playlist: %s",
+		conversion.ID, conversion.MatchedTracks, conversion.TotalTracks, conversion.FailedInserts, playlistURL)
 
 	return nil
 }
 
+// recordInsertedVideos stores the videos that made it into the playlist right away,
+// so a retry after a failure or crash skips them.
+func (c *converter) recordInsertedVideos(ctx context.Context, conversion *domain.Conversion, results []domain.VideoInsertResult) {
+	var inserted []string
+	for _, result := range results {
+		if !result.Failed() {
+			inserted = append(inserted, result.VideoID)
+		}
+	}
+	if len(inserted) == 0 {
+		return
+	}
+	conversion.RecordInsertedVideos(inserted)
+	c.saveState(ctx, conversion)
+}
+
+func (c *converter) recordMappings(ctx context.Context, matches []*domain.TrackMatch) {
+	for _, match := range matches {
+		if !match.IsMatched() || match.Confidence != domain.MatchConfidenceHigh || match.MatchMethod == mappingMatchMethod {
+			continue
+		}
+		c.recordVote(ctx, match, domain.MappingVoteMatch)
+	}
+}
+
+func (c *converter) recordCorrections(ctx context.Context, matches []*domain.TrackMatch, decisions []domain.ReviewDecision) {
+	reviewed := make(map[string]bool, len(decisions))
+	for _, decision := range decisions {
+		if !decision.IsRejection() {
+			reviewed[decision.SourceTrackID] = true
+		}
+	}
+
+	for _, match := range matches {
+		if reviewed[match.SourceTrack.PlatformID] && match.IsMatched() && match.MatchMethod == reviewMatchMethod {
+			c.recordVote(ctx, match, domain.MappingVoteCorrection)
+		}
+	}
+}
+
+func (c *converter) recordVote(ctx context.Context, match *domain.TrackMatch, vote domain.MappingVote) {
+	mapping, err := domain.NewTrackMapping(match.SourceTrack, match.TargetTrack)
+	if err != nil {
+		log.Printf("skipping track mapping for %q: %v", match.SourceTrack.Name, err)
+		return
+	}
+	if err := c.mappingRepo.RecordVote(ctx, mapping, vote); err != nil {
+		log.Printf("failed to record track mapping for %q: %v", match.SourceTrack.Name, err)
+	}
+}
+
 func (c *converter) handleError(ctx context.Context, conversion *domain.Conversion, message string, err error) error {
 	fullMessage := message
 	if err != nil {
@@ -182,6 +625,9 @@ func (c *converter) handleError(ctx context.Context, conversion *domain.Conversi
 	}
 
 	metrics.JobsFailed.WithLabelValues(message).Inc()
+	if conversion.Variant != "" {
+		metrics.ExperimentJobs.WithLabelValues(conversion.Experiment, conversion.Variant, string(domain.ConversionStatusFailed)).Inc()
+	}
 	conversion.Fail(fullMessage)
 	c.saveState(ctx, conversion)
 
@@ -197,10 +643,46 @@ func (c *converter) updateStatus(ctx context.Context, conversion *domain.Convers
 }
 
 func (c *converter) saveState(ctx context.Context, conversion *domain.Conversion) {
+	if err := c.persistState(ctx, conversion); err != nil {
+		log.Printf("failed to update conversion in dynamodb: %v", err)
+	}
+}
+
+// persistState is saveState for steps that must not run unless the conversion was stored.
+func (c *converter) persistState(ctx context.Context, conversion *domain.Conversion) error {
 	c.updateStatus(ctx, conversion)
+	return c.conversionRepo.Update(ctx, conversion)
+}
+
+// claim takes the conversion with a conditional write. It reports false when another
+// worker holds the conversion or changed it since it was loaded.
+func (c *converter) claim(ctx context.Context, conversion *domain.Conversion) (bool, error) {
+	if conversion.IsClaimed(time.Now()) {
+		return false, nil
+	}
+	conversion.Claim(c.config.JobTimeout)
 	if err := c.conversionRepo.Update(ctx, conversion); err != nil {
-		log.Printf("failed to update conversion in dynamodb: %v", err)
+		if errors.Is(err, ErrConversionClaimed) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to claim conversion: %w", err)
+	}
+	return true, nil
+}
+
+// dropClaimedJob acknowledges a job whose conversion another worker holds; that worker finishes it.
+func (c *converter) dropClaimedJob(conversionID string) error {
+	log.Printf("conversion %s claimed by another worker, dropping job", conversionID)
+	metrics.JobsSkipped.WithLabelValues("claimed").Inc()
+	return nil
+}
+
+// abortOnSaveError stops a job whose next step depends on a state change that could not be stored.
+func (c *converter) abortOnSaveError(ctx context.Context, conversion *domain.Conversion, message string, err error) error {
+	if errors.Is(err, ErrConversionClaimed) {
+		return c.dropClaimedJob(conversion.ID)
 	}
+	return c.handleError(ctx, conversion, message, err)
 }
 
 func filterTracks(tracks []*domain.Track, selectedIDs []string) []*domain.Track {
@@ -218,3 +700,209 @@ func filterTracks(tracks []*domain.Track, selectedIDs []string) []*domain.Track
 
 	return filtered
 }
+
+func applyReviewDecisions(conversionID string, matches []*domain.TrackMatch, decisions []domain.ReviewDecision) []*domain.ConversionLog {
+	decisionsBySource := make(map[string]domain.ReviewDecision, len(decisions))
+	for _, decision := range decisions {
+		decisionsBySource[decision.SourceTrackID] = decision
+	}
+
+	var logs []*domain.ConversionLog
+	for _, match := range matches {
+		decision, ok := decisionsBySource[match.SourceTrack.PlatformID]
+		if !ok {
+			continue
+		}
+
+		if decision.IsRejection() {
+			match.RejectReview()
+			logs = append(logs, domain.NewReviewTrackLog(conversionID, match.SourceTrack, nil, domain.LogStatusSkipped))
+			continue
+		}
+
+		target := match.FindCandidate(decision.TargetTrackID)
+		if target == nil {
+			reviewLog := domain.NewReviewTrackLog(conversionID, match.SourceTrack, nil, domain.LogStatusFailed)
+			reviewLog.ErrorMessage = fmt.Sprintf("target %s is not a candidate for this track", decision.TargetTrackID)
+			logs = append(logs, reviewLog)
+			continue
+		}
+
+		match.ApplyReview(target)
+		logs = append(logs, domain.NewReviewTrackLog(conversionID, match.SourceTrack, target, domain.LogStatusSuccess))
+	}
+
+	return logs
+}
+
+func expandDuplicates(tracks []*domain.Track, matches []*domain.TrackMatch, duplicates []domain.DuplicateTrack) []*domain.TrackMatch {
+	if len(duplicates) == 0 {
+		return matches
+	}
+
+	bySource := make(map[*domain.Track]*domain.TrackMatch, len(matches))
+	for _, match := range matches {
+		bySource[match.SourceTrack] = match
+	}
+	originals := make(map[*domain.Track]*domain.Track, len(duplicates))
+	for _, duplicate := range duplicates {
+		originals[duplicate.Track] = duplicate.Original
+	}
+
+	expanded := make([]*domain.TrackMatch, 0, len(tracks))
+	for _, track := range tracks {
+		if match, ok := bySource[track]; ok {
+			expanded = append(expanded, match)
+		} else if original, ok := bySource[originals[track]]; ok {
+			expanded = append(expanded, original.ForDuplicate(track))
+		}
+	}
+	return expanded
+}
+
+func duplicateLogs(conversionID string, duplicates []domain.DuplicateTrack, policy domain.DuplicatePolicy) []*domain.ConversionLog {
+	status := domain.LogStatusSuccess
+	if policy == domain.DuplicatePolicyDrop {
+		status = domain.LogStatusSkipped
+	} else {
+		policy = domain.DuplicatePolicyKeep
+	}
+
+	var logs []*domain.ConversionLog
+	for _, duplicate := range duplicates {
+		metrics.DuplicateTracks.WithLabelValues(string(policy)).Inc()
+		logs = append(logs, domain.NewDuplicateTrackLog(conversionID, duplicate, status))
+	}
+	return logs
+}
+
+func (c *converter) assignVariant(job *domain.ConversionJob, conversion *domain.Conversion) Matcher {
+	if c.experiment == nil {
+		return c.matcher
+	}
+
+	variant := c.experiment.Assign(job)
+	conversion.AssignVariant(c.experiment.Name, variant)
+	metrics.ExperimentAssignments.WithLabelValues(c.experiment.Name, variant).Inc()
+
+	return c.variantMatcher(variant)
+}
+
+func (c *converter) variantMatcher(variant string) Matcher {
+	if matcher, ok := c.variants[variant]; ok {
+		return matcher
+	}
+	return c.matcher
+}
+
+func (c *converter) loadCheckpoint(ctx context.Context, conversionID string) map[string]*domain.TrackMatch {
+	if c.checkpoints == nil {
+		return nil
+	}
+
+	matches, err := c.checkpoints.LoadMatches(ctx, conversionID)
+	if err != nil {
+		log.Printf("failed to load checkpoint for conversion %s: %v", conversionID, err)
+		return nil
+	}
+	return matches
+}
+
+func (c *converter) saveCheckpoint(ctx context.Context, conversionID string, match *domain.TrackMatch) {
+	// Only decided outcomes are kept. Tracks cut short by cancellation or a failed search were not
+	// really matched and must run again on redelivery.
+	if c.checkpoints == nil || ctx.Err() != nil || match.SearchFailed {
+		return
+	}
+
+	if err := c.checkpoints.SaveMatch(ctx, conversionID, match); err != nil {
+		log.Printf("failed to checkpoint track %s of conversion %s: %v", match.SourceTrack.PlatformID, conversionID, err)
+	}
+}
+
+func (c *converter) clearCheckpoint(ctx context.Context, conversionID string) {
+	if c.checkpoints == nil {
+		return
+	}
+
+	if err := c.checkpoints.Delete(ctx, conversionID); err != nil {
+		log.Printf("failed to delete checkpoint for conversion %s: %v", conversionID, err)
+	}
+}
+
+// mergeCheckpoint puts restored and freshly matched tracks back into source order.
+func mergeCheckpoint(tracks []*domain.Track, restored map[string]*domain.TrackMatch, matches []*domain.TrackMatch) []*domain.TrackMatch {
+	if len(restored) == 0 {
+		return matches
+	}
+
+	merged := make([]*domain.TrackMatch, 0, len(tracks))
+	next := 0
+	for _, track := range tracks {
+		if match, ok := restored[track.PlatformID]; ok {
+			match.SourceTrack = track
+			merged = append(merged, match)
+			continue
+		}
+		merged = append(merged, matches[next])
+		next++
+	}
+	return merged
+}
+
+func observeVariantMetrics(conversion *domain.Conversion, matches []*domain.TrackMatch) {
+	if conversion.Variant == "" {
+		return
+	}
+
+	for _, match := range matches {
+		result := "not_found"
+		switch {
+		case match.NeedsReview:
+			result = "needs_review"
+		case match.IsMatched():
+			result = "matched"
+		}
+		metrics.ExperimentTracks.WithLabelValues(conversion.Experiment, conversion.Variant, result).Inc()
+	}
+}
+
+func isrcStats(matches []*domain.TrackMatch) (withISRC, isrcHits int) {
+	for _, match := range matches {
+		if match.SourceTrack.ISRC == "" {
+			continue
+		}
+		withISRC++
+		if match.MatchMethod == isrcMatchMethod {
+			isrcHits++
+		}
+	}
+	return withISRC, isrcHits
+}
+
+func observeISRCMetrics(matches []*domain.TrackMatch) {
+	if len(matches) == 0 {
+		return
+	}
+
+	withISRC, isrcHits := isrcStats(matches)
+	metrics.JobISRCCoverage.Observe(float64(withISRC) / float64(len(matches)))
+	if withISRC > 0 {
+		metrics.JobISRCHitRate.Observe(float64(isrcHits) / float64(withISRC))
+	}
+}
+
+func countMatches(matches []*domain.TrackMatch) (processed, matched, needsReview, failed int) {
+	for _, match := range matches {
+		processed++
+		switch {
+		case match.NeedsReview:
+			needsReview++
+		case match.IsMatched():
+			matched++
+		default:
+			failed++
+		}
+	}
+	return processed, matched, needsReview, failed
+}
@@ -0,0 +1,1032 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/config"
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/domain"
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/infrastructure/http"
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/infrastructure/redis"
+)
+
+type mockSpotifyClient struct {
+	playlist *domain.Playlist
+}
+
+func (m *mockSpotifyClient) GetPlaylistTracks(ctx context.Context, playlistID, market, sessionID string) (*domain.Playlist, error) {
+	return m.playlist, nil
+}
+
+type memoryConversionRepository struct {
+	conversions map[string]domain.Conversion
+}
+
+func (r *memoryConversionRepository) Create(ctx context.Context, c *domain.Conversion) error {
+	if _, ok := r.conversions[c.ID]; ok {
+		return ErrConversionExists
+	}
+	c.Version = 1
+	r.conversions[c.ID] = *c
+	return nil
+}
+
+func (r *memoryConversionRepository) Update(ctx context.Context, c *domain.Conversion) error {
+	if stored, ok := r.conversions[c.ID]; !ok || stored.Version != c.Version {
+		return ErrConversionClaimed
+	}
+	c.Version++
+	r.conversions[c.ID] = *c
+	return nil
+}
+
+// racingConversionRepository hides conversions from Get, as if another worker created one after the lookup.
+type racingConversionRepository struct {
+	*memoryConversionRepository
+}
+
+func (r *racingConversionRepository) Get(ctx context.Context, id string) (*domain.Conversion, error) {
+	return nil, nil
+}
+
+// claimingConversionRepository changes the stored conversion after every Get, as if another worker
+// claimed it right after the lookup.
+type claimingConversionRepository struct {
+	*memoryConversionRepository
+}
+
+func (r *claimingConversionRepository) Get(ctx context.Context, id string) (*domain.Conversion, error) {
+	c, err := r.memoryConversionRepository.Get(ctx, id)
+	if stored, ok := r.conversions[id]; ok {
+		stored.Version++
+		r.conversions[id] = stored
+	}
+	return c, err
+}
+
+// playlistFailingConversionRepository cannot store a conversion once it has a target playlist.
+type playlistFailingConversionRepository struct {
+	*memoryConversionRepository
+}
+
+func (r *playlistFailingConversionRepository) Update(ctx context.Context, c *domain.Conversion) error {
+	if c.TargetPlaylistID != "" {
+		return errors.New("dynamodb unavailable")
+	}
+	return r.memoryConversionRepository.Update(ctx, c)
+}
+
+func (r *memoryConversionRepository) Get(ctx context.Context, id string) (*domain.Conversion, error) {
+	c, ok := r.conversions[id]
+	if !ok {
+		return nil, nil
+	}
+	return &c, nil
+}
+
+type memoryLogRepository struct {
+	logs []*domain.ConversionLog
+}
+
+func (r *memoryLogRepository) Create(ctx context.Context, log *domain.ConversionLog) error {
+	r.logs = append(r.logs, log)
+	return nil
+}
+
+func (r *memoryLogRepository) CreateBatch(ctx context.Context, logs []*domain.ConversionLog) error {
+	r.logs = append(r.logs, logs...)
+	return nil
+}
+
+func (r *memoryLogRepository) byStep(step domain.ConversionStep) []*domain.ConversionLog {
+	var logs []*domain.ConversionLog
+	for _, l := range r.logs {
+		if l.Step == step {
+			logs = append(logs, l)
+		}
+	}
+	return logs
+}
+
+type memoryMatchRepository struct {
+	matches map[string][]*domain.TrackMatch
+}
+
+func (r *memoryMatchRepository) SaveBatch(ctx context.Context, conversionID string, matches []*domain.TrackMatch) error {
+	r.matches[conversionID] = matches
+	return nil
+}
+
+func (r *memoryMatchRepository) ListByConversion(ctx context.Context, conversionID string) ([]*domain.TrackMatch, error) {
+	return r.matches[conversionID], nil
+}
+
+type memoryCheckpointStore struct {
+	matches     map[string]map[string]*domain.TrackMatch
+	saved       int
+	savedTracks []string
+}
+
+func (s *memoryCheckpointStore) SaveMatch(ctx context.Context, conversionID string, match *domain.TrackMatch) error {
+	if s.matches[conversionID] == nil {
+		s.matches[conversionID] = map[string]*domain.TrackMatch{}
+	}
+	s.matches[conversionID][match.SourceTrack.PlatformID] = match
+	s.saved++
+	s.savedTracks = append(s.savedTracks, match.SourceTrack.PlatformID)
+	return nil
+}
+
+func (s *memoryCheckpointStore) LoadMatches(ctx context.Context, conversionID string) (map[string]*domain.TrackMatch, error) {
+	return s.matches[conversionID], nil
+}
+
+func (s *memoryCheckpointStore) Delete(ctx context.Context, conversionID string) error {
+	delete(s.matches, conversionID)
+	return nil
+}
+
+type memoryMappingRepository struct {
+	mu       sync.Mutex
+	mappings map[string]*domain.TrackMapping
+}
+
+func newMemoryMappingRepository() *memoryMappingRepository {
+	return &memoryMappingRepository{mappings: map[string]*domain.TrackMapping{}}
+}
+
+func (r *memoryMappingRepository) FindBest(ctx context.Context, sourcePlatform domain.Platform, sourceID string, targetPlatform domain.Platform) (*domain.TrackMapping, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var candidates []*domain.TrackMapping
+	for _, m := range r.mappings {
+		if m.SourcePlatform == sourcePlatform && m.SourcePlatformID == sourceID && m.TargetPlatform == targetPlatform {
+			candidates = append(candidates, m)
+		}
+	}
+	return domain.BestMapping(candidates), nil
+}
+
+func (r *memoryMappingRepository) RecordVote(ctx context.Context, mapping *domain.TrackMapping, vote domain.MappingVote) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := mapping.SourcePlatformID + "->" + mapping.TargetPlatformID
+	existing, ok := r.mappings[key]
+	if !ok {
+		existing = mapping
+		r.mappings[key] = existing
+	}
+	if vote == domain.MappingVoteCorrection {
+		existing.CorrectionVotes++
+	} else {
+		existing.MatchVotes++
+	}
+	return nil
+}
+
+type memoryStatusStore struct {
+	statuses map[string]*redis.ConversionStatusData
+}
+
+func (s *memoryStatusStore) Set(ctx context.Context, status *redis.ConversionStatusData) error {
+	s.statuses[status.JobID] = status
+	return nil
+}
+
+func (s *memoryStatusStore) Get(ctx context.Context, jobID string) (*redis.ConversionStatusData, error) {
+	return s.statuses[jobID], nil
+}
+
+func (s *memoryStatusStore) Delete(ctx context.Context, jobID string) error {
+	delete(s.statuses, jobID)
+	return nil
+}
+
+type converterFixture struct {
+	converter      Converter
+	youtubeClient  *mockYouTubeClient
+	conversionRepo *memoryConversionRepository
+	logRepo        *memoryLogRepository
+	matchRepo      *memoryMatchRepository
+	mappingRepo    *memoryMappingRepository
+}
+
+func newConverterFixture(playlist *domain.Playlist, youtubeClient *mockYouTubeClient, opts ...ConverterOption) *converterFixture {
+	return newConverterFixtureWithClient(playlist, youtubeClient, youtubeClient, opts...)
+}
+
+// newConverterFixtureWithClient converts through client, which may wrap youtubeClient.
+func newConverterFixtureWithClient(playlist *domain.Playlist, youtubeClient *mockYouTubeClient, client http.YouTubeClient, opts ...ConverterOption) *converterFixture {
+	f := &converterFixture{
+		youtubeClient:  youtubeClient,
+		conversionRepo: &memoryConversionRepository{conversions: map[string]domain.Conversion{}},
+		logRepo:        &memoryLogRepository{},
+		matchRepo:      &memoryMatchRepository{matches: map[string][]*domain.TrackMatch{}},
+		mappingRepo:    newMemoryMappingRepository(),
+	}
+	f.converter = NewConverter(
+		&mockSpotifyClient{playlist: playlist},
+		client,
+		NewMatcher(client),
+		f.conversionRepo,
+		f.logRepo,
+		f.matchRepo,
+		f.mappingRepo,
+		&memoryStatusStore{statuses: map[string]*redis.ConversionStatusData{}},
+		config.WorkerConfig{Concurrency: 1, JobTimeout: time.Minute, MinMatchConfidence: "LOW"},
+		opts...,
+	)
+	return f
+}
+
+func reviewFixture() (*domain.Playlist, *mockYouTubeClient) {
+	playlist, _ := domain.NewPlaylist("Queen Hits", domain.PlatformSpotify, "playlist-1")
+	playlist.AddTracks([]*domain.Track{
+		mustSourceTrack("Bohemian Rhapsody", "Queen", "sp1"),
+		mustSourceTrack("Radio Ga Ga", "Queen", "sp2"),
+	})
+
+	ytExact, _ := domain.NewTrack("Queen - Bohemian Rhapsody", "Queen", domain.PlatformYouTube, "yt-exact")
+	ytUnrelated, _ := domain.NewTrack("Some Music Video", "RandomChannel", domain.PlatformYouTube, "yt-unrelated")
+	ytOther, _ := domain.NewTrack("Another Video", "OtherChannel", domain.PlatformYouTube, "yt-other")
+
+	youtubeClient := &mockYouTubeClient{
+		isrcResults: map[string]*domain.Track{},
+		trackResults: map[string][]*domain.Track{
+			"Bohemian Rhapsody|Queen": {ytExact},
+			"Radio Ga Ga|Queen":       {ytUnrelated, ytOther},
+		},
+	}
+
+	return playlist, youtubeClient
+}
+
+func TestConverter_PausesForReview(t *testing.T) {
+	playlist, youtubeClient := reviewFixture()
+	f := newConverterFixture(playlist, youtubeClient)
+
+	job := domain.NewConversionJob("user", domain.PlatformSpotify, domain.PlatformYouTube, "playlist-1", "Converted")
+	job.MatchOptions = domain.MatchOptions{MinConfidence: domain.MatchConfidenceMedium, RequireReview: true}
+
+	if err := f.converter.Convert(context.Background(), job); err != nil {
+		t.Fatalf("Convert() error: %v", err)
+	}
+
+	conversion, _ := f.conversionRepo.Get(context.Background(), job.JobID)
+	if conversion.Status != domain.ConversionStatusAwaitingReview {
+		t.Errorf("status = %v, want AWAITING_REVIEW", conversion.Status)
+	}
+	if conversion.ReviewTracks != 1 {
+		t.Errorf("ReviewTracks = %d, want 1", conversion.ReviewTracks)
+	}
+	if len(f.matchRepo.matches[job.JobID]) != 2 {
+		t.Errorf("expected 2 stored matches, got %d", len(f.matchRepo.matches[job.JobID]))
+	}
+	if len(youtubeClient.addedVideoIDs) != 0 {
+		t.Errorf("expected no videos added before review, got %v", youtubeClient.addedVideoIDs)
+	}
+}
+
+func TestConverter_ApplyReviewCompletesConversion(t *testing.T) {
+	playlist, youtubeClient := reviewFixture()
+	f := newConverterFixture(playlist, youtubeClient)
+
+	job := domain.NewConversionJob("user", domain.PlatformSpotify, domain.PlatformYouTube, "playlist-1", "Converted")
+	job.MatchOptions = domain.MatchOptions{MinConfidence: domain.MatchConfidenceMedium, RequireReview: true}
+
+	if err := f.converter.Convert(context.Background(), job); err != nil {
+		t.Fatalf("Convert() error: %v", err)
+	}
+
+	review := domain.NewReviewDecisionJob(job.JobID, "user", []domain.ReviewDecision{
+		{SourceTrackID: "sp2", TargetTrackID: "yt-other"},
+	})
+	if err := f.converter.ApplyReview(context.Background(), review); err != nil {
+		t.Fatalf("ApplyReview() error: %v", err)
+	}
+
+	conversion, _ := f.conversionRepo.Get(context.Background(), job.JobID)
+	if conversion.Status != domain.ConversionStatusCompleted {
+		t.Errorf("status = %v, want COMPLETED", conversion.Status)
+	}
+	if conversion.MatchedTracks != 2 || conversion.ReviewTracks != 0 {
+		t.Errorf("matched/review = %d/%d, want 2/0", conversion.MatchedTracks, conversion.ReviewTracks)
+	}
+
+	added := map[string]bool{}
+	for _, id := range youtubeClient.addedVideoIDs {
+		added[id] = true
+	}
+	if len(added) != 2 || !added["yt-exact"] || !added["yt-other"] {
+		t.Errorf("expected yt-exact and yt-other to be added, got %v", youtubeClient.addedVideoIDs)
+	}
+
+	reviewLogs := f.logRepo.byStep(domain.StepReviewTrack)
+	if len(reviewLogs) != 1 || reviewLogs[0].TargetTrackID != "yt-other" {
+		t.Errorf("expected one review log for yt-other, got %+v", reviewLogs)
+	}
+}
+
+func TestConverter_RecordsMappings(t *testing.T) {
+	playlist, youtubeClient := reviewFixture()
+	f := newConverterFixture(playlist, youtubeClient)
+
+	job := domain.NewConversionJob("user", domain.PlatformSpotify, domain.PlatformYouTube, "playlist-1", "Converted")
+	job.MatchOptions = domain.MatchOptions{MinConfidence: domain.MatchConfidenceMedium, RequireReview: true}
+
+	if err := f.converter.Convert(context.Background(), job); err != nil {
+		t.Fatalf("Convert() error: %v", err)
+	}
+
+	exact := f.mappingRepo.mappings["sp1->yt-exact"]
+	if exact == nil || exact.MatchVotes != 1 {
+		t.Fatalf("expected one match vote for sp1->yt-exact, got %+v", exact)
+	}
+	if _, ok := f.mappingRepo.mappings["sp2->yt-unrelated"]; ok {
+		t.Error("low confidence matches should not be recorded as mappings")
+	}
+
+	review := domain.NewReviewDecisionJob(job.JobID, "user", []domain.ReviewDecision{
+		{SourceTrackID: "sp2", TargetTrackID: "yt-other"},
+	})
+	if err := f.converter.ApplyReview(context.Background(), review); err != nil {
+		t.Fatalf("ApplyReview() error: %v", err)
+	}
+
+	corrected := f.mappingRepo.mappings["sp2->yt-other"]
+	if corrected == nil || corrected.CorrectionVotes != 1 {
+		t.Errorf("expected one correction vote for sp2->yt-other, got %+v", corrected)
+	}
+}
+
+func TestConverter_ApplyReviewRejectsUnknownCandidate(t *testing.T) {
+	playlist, youtubeClient := reviewFixture()
+	f := newConverterFixture(playlist, youtubeClient)
+
+	job := domain.NewConversionJob("user", domain.PlatformSpotify, domain.PlatformYouTube, "playlist-1", "Converted")
+	job.MatchOptions = domain.MatchOptions{MinConfidence: domain.MatchConfidenceMedium, RequireReview: true}
+	f.converter.Convert(context.Background(), job)
+
+	review := domain.NewReviewDecisionJob(job.JobID, "user", []domain.ReviewDecision{
+		{SourceTrackID: "sp2", TargetTrackID: "yt-not-a-candidate"},
+	})
+	if err := f.converter.ApplyReview(context.Background(), review); err != nil {
+		t.Fatalf("ApplyReview() error: %v", err)
+	}
+
+	if len(youtubeClient.addedVideoIDs) != 1 || youtubeClient.addedVideoIDs[0] != "yt-exact" {
+		t.Errorf("expected only yt-exact to be added, got %v", youtubeClient.addedVideoIDs)
+	}
+
+	reviewLogs := f.logRepo.byStep(domain.StepReviewTrack)
+	if len(reviewLogs) != 1 || reviewLogs[0].Status != domain.LogStatusFailed {
+		t.Errorf("expected one failed review log, got %+v", reviewLogs)
+	}
+}
+
+func TestConverter_ApplyReviewRequiresPausedConversion(t *testing.T) {
+	playlist, youtubeClient := reviewFixture()
+	f := newConverterFixture(playlist, youtubeClient)
+
+	review := domain.NewReviewDecisionJob("missing", "user", nil)
+	if err := f.converter.ApplyReview(context.Background(), review); err == nil {
+		t.Error("expected error for unknown conversion")
+	}
+}
+
+func TestConverter_SwapTracksReplacesVideo(t *testing.T) {
+	playlist, youtubeClient := reviewFixture()
+	f := newConverterFixture(playlist, youtubeClient)
+
+	job := domain.NewConversionJob("user", domain.PlatformSpotify, domain.PlatformYouTube, "playlist-1", "Converted")
+	if err := f.converter.Convert(context.Background(), job); err != nil {
+		t.Fatalf("Convert() error: %v", err)
+	}
+
+	swap := domain.NewSwapTrackJob(job.JobID, "user", []domain.TrackSwap{
+		{SourceTrackID: "sp2", TargetTrackID: "yt-other"},
+		{SourceTrackID: "sp1", TargetTrackID: "yt-not-a-candidate"},
+	})
+	if err := f.converter.SwapTracks(context.Background(), swap); err != nil {
+		t.Fatalf("SwapTracks() error: %v", err)
+	}
+
+	if len(youtubeClient.replacedVideoIDs) != 1 || youtubeClient.replacedVideoIDs[0] != "yt-unrelated->yt-other" {
+		t.Errorf("expected yt-unrelated to be replaced by yt-other, got %v", youtubeClient.replacedVideoIDs)
+	}
+
+	swapLogs := f.logRepo.byStep(domain.StepSwapTrack)
+	if len(swapLogs) != 2 {
+		t.Fatalf("expected 2 swap logs, got %d", len(swapLogs))
+	}
+	if swapLogs[0].Status != domain.LogStatusSuccess || swapLogs[0].PreviousTargetID != "yt-unrelated" || swapLogs[0].TargetTrackID != "yt-other" {
+		t.Errorf("unexpected swap log %+v", swapLogs[0])
+	}
+	if swapLogs[1].Status != domain.LogStatusFailed {
+		t.Errorf("expected unknown candidate swap to fail, got %+v", swapLogs[1])
+	}
+
+	for _, match := range f.matchRepo.matches[job.JobID] {
+		if match.SourceTrack.PlatformID == "sp2" && (match.TargetTrack.PlatformID != "yt-other" || match.MatchMethod != domain.MatchMethodUserSwap) {
+			t.Errorf("expected stored match to point at yt-other, got %+v", match)
+		}
+	}
+
+	corrected := f.mappingRepo.mappings["sp2->yt-other"]
+	if corrected == nil || corrected.CorrectionVotes != 1 {
+		t.Errorf("expected one correction vote for sp2->yt-other, got %+v", corrected)
+	}
+}
+
+func TestConverter_SwapTracksRequiresCompletedConversion(t *testing.T) {
+	playlist, youtubeClient := reviewFixture()
+	f := newConverterFixture(playlist, youtubeClient)
+
+	job := domain.NewConversionJob("user", domain.PlatformSpotify, domain.PlatformYouTube, "playlist-1", "Converted")
+	job.MatchOptions = domain.MatchOptions{MinConfidence: domain.MatchConfidenceMedium, RequireReview: true}
+	f.converter.Convert(context.Background(), job)
+
+	swap := domain.NewSwapTrackJob(job.JobID, "user", []domain.TrackSwap{{SourceTrackID: "sp2", TargetTrackID: "yt-other"}})
+	if err := f.converter.SwapTracks(context.Background(), swap); err == nil {
+		t.Error("expected error when swapping before the playlist exists")
+	}
+}
+
+func duplicateFixture() (*domain.Playlist, *mockYouTubeClient) {
+	playlist, _ := domain.NewPlaylist("Queen Hits", domain.PlatformSpotify, "playlist-1")
+	playlist.AddTracks([]*domain.Track{
+		mustSourceTrack("Bohemian Rhapsody", "Queen", "sp-single").WithISRC("GBUM71029604"),
+		mustSourceTrack("Radio Ga Ga", "Queen", "sp2"),
+		mustSourceTrack("Bohemian Rhapsody", "Queen", "sp-album").WithISRC("GBUM71029604"),
+	})
+
+	ytExact, _ := domain.NewTrack("Queen - Bohemian Rhapsody", "Queen", domain.PlatformYouTube, "yt-exact")
+	ytRadio, _ := domain.NewTrack("Queen - Radio Ga Ga", "Queen", domain.PlatformYouTube, "yt-radio")
+
+	youtubeClient := &mockYouTubeClient{
+		isrcResults: map[string]*domain.Track{"GBUM71029604": ytExact},
+		trackResults: map[string][]*domain.Track{
+			"Radio Ga Ga|Queen": {ytRadio},
+		},
+	}
+
+	return playlist, youtubeClient
+}
+
+func TestConverter_KeepsDuplicatesMatchedOnce(t *testing.T) {
+	playlist, youtubeClient := duplicateFixture()
+	f := newConverterFixture(playlist, youtubeClient)
+
+	job := domain.NewConversionJob("user", domain.PlatformSpotify, domain.PlatformYouTube, "playlist-1", "Converted")
+	if err := f.converter.Convert(context.Background(), job); err != nil {
+		t.Fatalf("Convert() error: %v", err)
+	}
+
+	if calls := youtubeClient.searchCalls.Load(); calls != 2 {
+		t.Errorf("expected 2 searches for 2 unique tracks, got %d", calls)
+	}
+	if len(youtubeClient.addedVideoIDs) != 3 || youtubeClient.addedVideoIDs[2] != "yt-exact" {
+		t.Errorf("expected duplicate to be added in source order, got %v", youtubeClient.addedVideoIDs)
+	}
+
+	dupLogs := f.logRepo.byStep(domain.StepDeduplicateTrack)
+	if len(dupLogs) != 1 || dupLogs[0].SourceTrackID != "sp-album" || dupLogs[0].DuplicateOfID != "sp-single" || dupLogs[0].Status != domain.LogStatusSuccess {
+		t.Errorf("unexpected duplicate logs %+v", dupLogs)
+	}
+
+	conversion, _ := f.conversionRepo.Get(context.Background(), job.JobID)
+	if conversion.TotalTracks != 3 || conversion.MatchedTracks != 3 {
+		t.Errorf("total/matched = %d/%d, want 3/3", conversion.TotalTracks, conversion.MatchedTracks)
+	}
+}
+
+func TestConverter_DropsDuplicates(t *testing.T) {
+	playlist, youtubeClient := duplicateFixture()
+	f := newConverterFixture(playlist, youtubeClient)
+
+	job := domain.NewConversionJob("user", domain.PlatformSpotify, domain.PlatformYouTube, "playlist-1", "Converted")
+	job.MatchOptions = domain.MatchOptions{Duplicates: domain.DuplicatePolicyDrop}
+	if err := f.converter.Convert(context.Background(), job); err != nil {
+		t.Fatalf("Convert() error: %v", err)
+	}
+
+	if len(youtubeClient.addedVideoIDs) != 2 {
+		t.Errorf("expected duplicate to be dropped, got %v", youtubeClient.addedVideoIDs)
+	}
+
+	dupLogs := f.logRepo.byStep(domain.StepDeduplicateTrack)
+	if len(dupLogs) != 1 || dupLogs[0].Status != domain.LogStatusSkipped {
+		t.Errorf("expected one skipped duplicate log, got %+v", dupLogs)
+	}
+
+	conversion, _ := f.conversionRepo.Get(context.Background(), job.JobID)
+	if conversion.TotalTracks != 2 {
+		t.Errorf("TotalTracks = %d, want 2", conversion.TotalTracks)
+	}
+}
+
+func TestConverter_ExperimentUsesVariantMatcher(t *testing.T) {
+	playlist, youtubeClient := reviewFixture()
+	experiment, _ := domain.NewExperiment("isrc_only", domain.ExperimentBucketUser, []domain.Variant{{Name: "treatment", Weight: 1}})
+	variantMatcher := NewMatcher(youtubeClient, WithStrategies([]string{StrategyISRC}))
+	f := newConverterFixture(playlist, youtubeClient, WithExperiment(experiment, map[string]Matcher{"treatment": variantMatcher}))
+
+	job := domain.NewConversionJob("user", domain.PlatformSpotify, domain.PlatformYouTube, "playlist-1", "Converted")
+	if err := f.converter.Convert(context.Background(), job); err == nil {
+		t.Fatal("expected the ISRC-only variant to fail the conversion")
+	}
+
+	conversion, _ := f.conversionRepo.Get(context.Background(), job.JobID)
+	if conversion.Experiment != "isrc_only" || conversion.Variant != "treatment" {
+		t.Errorf("Experiment/Variant = %q/%q, want isrc_only/treatment", conversion.Experiment, conversion.Variant)
+	}
+	if conversion.MatchedTracks != 0 {
+		t.Errorf("expected the ISRC-only variant to match nothing, got %d", conversion.MatchedTracks)
+	}
+
+	matchLogs := 0
+	for _, l := range f.logRepo.logs {
+		if l.Step != domain.StepMatchTrack {
+			continue
+		}
+		matchLogs++
+		if l.Variant != "treatment" {
+			t.Errorf("match log variant = %q, want treatment", l.Variant)
+		}
+	}
+	if matchLogs != 2 {
+		t.Errorf("expected 2 match logs, got %d", matchLogs)
+	}
+}
+
+func TestISRCStats(t *testing.T) {
+	withISRC := mustSourceTrack("Bohemian Rhapsody", "Queen", "sp1").WithISRC("GBUM71029604")
+	otherWithISRC := mustSourceTrack("Radio Ga Ga", "Queen", "sp2").WithISRC("GBUM71029605")
+	withoutISRC := mustSourceTrack("Under Pressure", "Queen", "sp3")
+	target, _ := domain.NewTrack("Bohemian Rhapsody", "Queen", domain.PlatformYouTube, "yt1")
+
+	matches := []*domain.TrackMatch{
+		domain.NewTrackMatch(withISRC, target, domain.MatchConfidenceHigh, "isrc"),
+		domain.NewTrackMatch(otherWithISRC, target, domain.MatchConfidenceHigh, "exact_match"),
+		domain.NewFailedMatch(withoutISRC, "no match found"),
+	}
+
+	total, hits := isrcStats(matches)
+	if total != 2 || hits != 1 {
+		t.Errorf("isrcStats() = (%d, %d), want (2, 1)", total, hits)
+	}
+}
+
+func resumeFixture() (*domain.Playlist, *mockYouTubeClient) {
+	playlist, _ := domain.NewPlaylist("Queen Hits", domain.PlatformSpotify, "playlist-1")
+	playlist.AddTracks([]*domain.Track{
+		mustSourceTrack("Bohemian Rhapsody", "Queen", "sp1"),
+		mustSourceTrack("Radio Ga Ga", "Queen", "sp2"),
+	})
+
+	ytBohemian, _ := domain.NewTrack("Queen - Bohemian Rhapsody", "Queen", domain.PlatformYouTube, "yt-bohemian")
+	ytRadio, _ := domain.NewTrack("Queen - Radio Ga Ga", "Queen", domain.PlatformYouTube, "yt-radio")
+
+	youtubeClient := &mockYouTubeClient{
+		isrcResults: map[string]*domain.Track{},
+		trackResults: map[string][]*domain.Track{
+			"Bohemian Rhapsody|Queen": {ytBohemian},
+			"Radio Ga Ga|Queen":       {ytRadio},
+		},
+	}
+	return playlist, youtubeClient
+}
+
+func TestConverter_ResumesMatchingFromCheckpoint(t *testing.T) {
+	playlist, youtubeClient := resumeFixture()
+	checkpoints := &memoryCheckpointStore{matches: map[string]map[string]*domain.TrackMatch{}}
+	f := newConverterFixture(playlist, youtubeClient, WithCheckpoints(checkpoints))
+
+	job := domain.NewConversionJob("user", domain.PlatformSpotify, domain.PlatformYouTube, "playlist-1", "Converted")
+	conversion, _ := domain.NewConversion(job)
+	conversion.StartMatching(2, "Queen Hits")
+	f.conversionRepo.Create(context.Background(), conversion)
+
+	ytBohemian, _ := domain.NewTrack("Queen - Bohemian Rhapsody", "Queen", domain.PlatformYouTube, "yt-bohemian")
+	checkpoints.SaveMatch(context.Background(), job.JobID,
+		domain.NewTrackMatch(mustSourceTrack("Bohemian Rhapsody", "Queen", "sp1"), ytBohemian, domain.MatchConfidenceHigh, "search"))
+
+	if err := f.converter.Convert(context.Background(), job); err != nil {
+		t.Fatalf("Convert() error: %v", err)
+	}
+
+	if calls := youtubeClient.searchCalls.Load(); calls != 1 {
+		t.Errorf("expected only the unfinished track to be searched, got %d searches", calls)
+	}
+	if len(youtubeClient.addedVideoIDs) != 2 || youtubeClient.addedVideoIDs[0] != "yt-bohemian" || youtubeClient.addedVideoIDs[1] != "yt-radio" {
+		t.Errorf("expected restored and new matches in source order, got %v", youtubeClient.addedVideoIDs)
+	}
+
+	saved, _ := f.conversionRepo.Get(context.Background(), job.JobID)
+	if saved.Status != domain.ConversionStatusCompleted || saved.MatchedTracks != 2 {
+		t.Errorf("status/matched = %s/%d, want COMPLETED/2", saved.Status, saved.MatchedTracks)
+	}
+	if _, ok := checkpoints.matches[job.JobID]; ok {
+		t.Error("expected checkpoint to be cleared once matches are stored")
+	}
+}
+
+func TestConverter_CheckpointsEachMatch(t *testing.T) {
+	playlist, youtubeClient := resumeFixture()
+	checkpoints := &memoryCheckpointStore{matches: map[string]map[string]*domain.TrackMatch{}}
+	f := newConverterFixture(playlist, youtubeClient, WithCheckpoints(checkpoints))
+
+	job := domain.NewConversionJob("user", domain.PlatformSpotify, domain.PlatformYouTube, "playlist-1", "Converted")
+	if err := f.converter.Convert(context.Background(), job); err != nil {
+		t.Fatalf("Convert() error: %v", err)
+	}
+
+	if checkpoints.saved != 2 {
+		t.Errorf("expected every finished match to be checkpointed, got %d saves", checkpoints.saved)
+	}
+}
+
+func TestConverter_DoesNotCheckpointFailedSearches(t *testing.T) {
+	_, youtubeClient := resumeFixture()
+	youtubeClient.trackErrors = map[string]error{"Radio Ga Ga": errors.New("quota exceeded")}
+	playlist, _ := domain.NewPlaylist("Queen Hits", domain.PlatformSpotify, "playlist-1")
+	playlist.AddTracks([]*domain.Track{
+		mustSourceTrack("Bohemian Rhapsody", "Queen", "sp1"),
+		mustSourceTrack("Radio Ga Ga", "Queen", "sp2"),
+		mustSourceTrack("Unreleased Demo", "Queen", "sp3"),
+	})
+	checkpoints := &memoryCheckpointStore{matches: map[string]map[string]*domain.TrackMatch{}}
+	f := newConverterFixture(playlist, youtubeClient, WithCheckpoints(checkpoints))
+
+	job := domain.NewConversionJob("user", domain.PlatformSpotify, domain.PlatformYouTube, "playlist-1", "Converted")
+	if err := f.converter.Convert(context.Background(), job); err != nil {
+		t.Fatalf("Convert() error: %v", err)
+	}
+
+	// Checkpoints are saved in the order matches finish, which varies between runs.
+	saved := slices.Sorted(slices.Values(checkpoints.savedTracks))
+	if !slices.Equal(saved, []string{"sp1", "sp3"}) {
+		t.Errorf("expected the match and the confirmed miss to be checkpointed, got %v", saved)
+	}
+}
+
+func TestConverter_ResumesPlaylistCreation(t *testing.T) {
+	playlist, youtubeClient := resumeFixture()
+	f := newConverterFixture(playlist, youtubeClient)
+
+	job := domain.NewConversionJob("user", domain.PlatformSpotify, domain.PlatformYouTube, "playlist-1", "Converted")
+	conversion, _ := domain.NewConversion(job)
+	conversion.StartMatching(2, "Queen Hits")
+	conversion.StartCreating()
+	f.conversionRepo.Create(context.Background(), conversion)
+
+	ytBohemian, _ := domain.NewTrack("Queen - Bohemian Rhapsody", "Queen", domain.PlatformYouTube, "yt-bohemian")
+	f.matchRepo.SaveBatch(context.Background(), job.JobID, []*domain.TrackMatch{
+		domain.NewTrackMatch(mustSourceTrack("Bohemian Rhapsody", "Queen", "sp1"), ytBohemian, domain.MatchConfidenceHigh, "search"),
+	})
+
+	if err := f.converter.Convert(context.Background(), job); err != nil {
+		t.Fatalf("Convert() error: %v", err)
+	}
+
+	if calls := youtubeClient.searchCalls.Load(); calls != 0 {
+		t.Errorf("expected no searches when resuming playlist creation, got %d", calls)
+	}
+	if len(youtubeClient.addedVideoIDs) != 1 || youtubeClient.addedVideoIDs[0] != "yt-bohemian" {
+		t.Errorf("expected stored matches to be added, got %v", youtubeClient.addedVideoIDs)
+	}
+}
+
+func TestConverter_IgnoresRedeliveryWhileAwaitingReview(t *testing.T) {
+	playlist, youtubeClient := reviewFixture()
+	f := newConverterFixture(playlist, youtubeClient)
+
+	job := domain.NewConversionJob("user", domain.PlatformSpotify, domain.PlatformYouTube, "playlist-1", "Converted")
+	job.MatchOptions = domain.MatchOptions{MinConfidence: domain.MatchConfidenceMedium, RequireReview: true}
+	if err := f.converter.Convert(context.Background(), job); err != nil {
+		t.Fatalf("Convert() error: %v", err)
+	}
+	searches := youtubeClient.searchCalls.Load()
+
+	if err := f.converter.Convert(context.Background(), job); err != nil {
+		t.Fatalf("redelivered Convert() error: %v", err)
+	}
+
+	if calls := youtubeClient.searchCalls.Load(); calls != searches {
+		t.Errorf("expected no new searches for a redelivered job, got %d", calls-searches)
+	}
+	conversion, _ := f.conversionRepo.Get(context.Background(), job.JobID)
+	if conversion.Status != domain.ConversionStatusAwaitingReview {
+		t.Errorf("status = %s, want AWAITING_REVIEW", conversion.Status)
+	}
+}
+
+func TestConverter_AcknowledgesCompletedJob(t *testing.T) {
+	playlist, youtubeClient := resumeFixture()
+	f := newConverterFixture(playlist, youtubeClient)
+
+	job := domain.NewConversionJob("user", domain.PlatformSpotify, domain.PlatformYouTube, "playlist-1", "Converted")
+	if err := f.converter.Convert(context.Background(), job); err != nil {
+		t.Fatalf("Convert() error: %v", err)
+	}
+	searches := youtubeClient.searchCalls.Load()
+
+	if err := f.converter.Convert(context.Background(), job); err != nil {
+		t.Fatalf("redelivered Convert() error: %v", err)
+	}
+
+	if youtubeClient.createdPlaylists != 1 {
+		t.Errorf("expected a single playlist, got %d", youtubeClient.createdPlaylists)
+	}
+	if calls := youtubeClient.searchCalls.Load(); calls != searches {
+		t.Errorf("expected no new searches for a completed job, got %d", calls-searches)
+	}
+	if len(youtubeClient.addedVideoIDs) != 2 {
+		t.Errorf("expected videos to be added once, got %v", youtubeClient.addedVideoIDs)
+	}
+}
+
+func TestConverter_RetryReusesTargetPlaylist(t *testing.T) {
+	playlist, youtubeClient := resumeFixture()
+	f := newConverterFixture(playlist, youtubeClient)
+
+	job := domain.NewConversionJob("user", domain.PlatformSpotify, domain.PlatformYouTube, "playlist-1", "Converted")
+	conversion, _ := domain.NewConversion(job)
+	conversion.StartCreating()
+	conversion.AttachTargetPlaylist("playlist-existing", "https://youtube.com/playlist?list=existing")
+	conversion.Fail("failed to add videos to playlist: timeout")
+	f.conversionRepo.Create(context.Background(), conversion)
+
+	ytBohemian, _ := domain.NewTrack("Queen - Bohemian Rhapsody", "Queen", domain.PlatformYouTube, "yt-bohemian")
+	f.matchRepo.SaveBatch(context.Background(), job.JobID, []*domain.TrackMatch{
+		domain.NewTrackMatch(mustSourceTrack("Bohemian Rhapsody", "Queen", "sp1"), ytBohemian, domain.MatchConfidenceHigh, "search"),
+	})
+
+	if err := f.converter.Convert(context.Background(), job); err != nil {
+		t.Fatalf("Convert() error: %v", err)
+	}
+
+	if youtubeClient.createdPlaylists != 0 {
+		t.Errorf("expected the recorded playlist to be reused, got %d new playlists", youtubeClient.createdPlaylists)
+	}
+	saved, _ := f.conversionRepo.Get(context.Background(), job.JobID)
+	if saved.Status != domain.ConversionStatusCompleted || saved.TargetPlaylistID != "playlist-existing" || saved.ErrorMessage != "" {
+		t.Errorf("status/playlist/error = %s/%s/%q, want COMPLETED/playlist-existing/\"\"", saved.Status, saved.TargetPlaylistID, saved.ErrorMessage)
+	}
+}
+
+func TestConverter_RecordsTargetPlaylistBeforeAddingVideos(t *testing.T) {
+	playlist, youtubeClient := resumeFixture()
+	f := newConverterFixture(playlist, youtubeClient)
+
+	job := domain.NewConversionJob("user", domain.PlatformSpotify, domain.PlatformYouTube, "playlist-1", "Converted")
+	var recorded string
+	youtubeClient.onAddVideos = func() {
+		conversion, _ := f.conversionRepo.Get(context.Background(), job.JobID)
+		recorded = conversion.TargetPlaylistID
+	}
+
+	if err := f.converter.Convert(context.Background(), job); err != nil {
+		t.Fatalf("Convert() error: %v", err)
+	}
+
+	if recorded != "playlist-id" {
+		t.Errorf("expected target playlist to be stored before adding videos, got %q", recorded)
+	}
+}
+
+func TestConverter_DropsJobClaimedByAnotherWorker(t *testing.T) {
+	playlist, youtubeClient := resumeFixture()
+	f := newConverterFixture(playlist, youtubeClient)
+	repo := &racingConversionRepository{f.conversionRepo}
+	c := f.converter.(*converter)
+	c.conversionRepo = repo
+
+	job := domain.NewConversionJob("user", domain.PlatformSpotify, domain.PlatformYouTube, "playlist-1", "Converted")
+	conversion, _ := domain.NewConversion(job)
+	f.conversionRepo.Create(context.Background(), conversion)
+
+	if err := f.converter.Convert(context.Background(), job); err != nil {
+		t.Errorf("expected a claimed job to be acknowledged, got %v", err)
+	}
+	if calls := youtubeClient.searchCalls.Load(); calls != 0 {
+		t.Errorf("expected no work for a claimed job, got %d searches", calls)
+	}
+}
+
+func TestConverter_DropsResumeHeldByAnotherWorker(t *testing.T) {
+	playlist, youtubeClient := resumeFixture()
+	f := newConverterFixture(playlist, youtubeClient)
+
+	job := domain.NewConversionJob("user", domain.PlatformSpotify, domain.PlatformYouTube, "playlist-1", "Converted")
+	conversion, _ := domain.NewConversion(job)
+	conversion.StartMatching(2, "Queen Hits")
+	conversion.Claim(time.Minute)
+	f.conversionRepo.Create(context.Background(), conversion)
+
+	if err := f.converter.Convert(context.Background(), job); err != nil {
+		t.Errorf("expected a held conversion to be acknowledged, got %v", err)
+	}
+	if calls := youtubeClient.searchCalls.Load(); calls != 0 {
+		t.Errorf("expected no work for a held conversion, got %d searches", calls)
+	}
+}
+
+func TestConverter_DropsResumeWhenClaimIsLost(t *testing.T) {
+	playlist, youtubeClient := resumeFixture()
+	f := newConverterFixture(playlist, youtubeClient)
+	c := f.converter.(*converter)
+	c.conversionRepo = &claimingConversionRepository{f.conversionRepo}
+
+	job := domain.NewConversionJob("user", domain.PlatformSpotify, domain.PlatformYouTube, "playlist-1", "Converted")
+	conversion, _ := domain.NewConversion(job)
+	conversion.Fail("failed to fetch playlist: timeout")
+	f.conversionRepo.Create(context.Background(), conversion)
+
+	if err := f.converter.Convert(context.Background(), job); err != nil {
+		t.Errorf("expected a lost claim to be acknowledged, got %v", err)
+	}
+	if calls := youtubeClient.searchCalls.Load(); calls != 0 {
+		t.Errorf("expected no work after losing the claim, got %d searches", calls)
+	}
+	saved, _ := f.conversionRepo.Get(context.Background(), job.JobID)
+	if saved.Status != domain.ConversionStatusFailed {
+		t.Errorf("status = %s, want the other worker's FAILED to be kept", saved.Status)
+	}
+}
+
+func TestConverter_ApplyReviewDropsDuplicateDecision(t *testing.T) {
+	tests := []struct {
+		name    string
+		prepare func(f *converterFixture, conversionID string)
+	}{
+		{"review in progress", func(f *converterFixture, conversionID string) {
+			conversion, _ := f.conversionRepo.Get(context.Background(), conversionID)
+			conversion.Claim(time.Minute)
+			f.conversionRepo.Update(context.Background(), conversion)
+		}},
+		{"claim lost", func(f *converterFixture, conversionID string) {
+			f.converter.(*converter).conversionRepo = &claimingConversionRepository{f.conversionRepo}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			playlist, youtubeClient := reviewFixture()
+			f := newConverterFixture(playlist, youtubeClient)
+
+			job := domain.NewConversionJob("user", domain.PlatformSpotify, domain.PlatformYouTube, "playlist-1", "Converted")
+			job.MatchOptions = domain.MatchOptions{MinConfidence: domain.MatchConfidenceMedium, RequireReview: true}
+			if err := f.converter.Convert(context.Background(), job); err != nil {
+				t.Fatalf("Convert() error: %v", err)
+			}
+			tt.prepare(f, job.JobID)
+
+			review := domain.NewReviewDecisionJob(job.JobID, "user", []domain.ReviewDecision{
+				{SourceTrackID: "sp2", TargetTrackID: "yt-other"},
+			})
+			if err := f.converter.ApplyReview(context.Background(), review); err != nil {
+				t.Errorf("expected a duplicate review to be acknowledged, got %v", err)
+			}
+			if youtubeClient.createdPlaylists != 0 {
+				t.Errorf("expected no playlist for a duplicate review, got %d", youtubeClient.createdPlaylists)
+			}
+		})
+	}
+}
+
+func TestConverter_RetrySkipsInsertedVideos(t *testing.T) {
+	playlist, youtubeClient := resumeFixture()
+	f := newConverterFixture(playlist, youtubeClient)
+
+	job := domain.NewConversionJob("user", domain.PlatformSpotify, domain.PlatformYouTube, "playlist-1", "Converted")
+	conversion, _ := domain.NewConversion(job)
+	conversion.StartCreating()
+	conversion.AttachTargetPlaylist("playlist-existing", "https://youtube.com/playlist?list=existing")
+	conversion.RecordInsertedVideos([]string{"yt-bohemian"})
+	conversion.Fail("failed to add videos to playlist: timeout")
+	f.conversionRepo.Create(context.Background(), conversion)
+
+	ytBohemian, _ := domain.NewTrack("Queen - Bohemian Rhapsody", "Queen", domain.PlatformYouTube, "yt-bohemian")
+	ytRadio, _ := domain.NewTrack("Queen - Radio Ga Ga", "Queen", domain.PlatformYouTube, "yt-radio")
+	f.matchRepo.SaveBatch(context.Background(), job.JobID, []*domain.TrackMatch{
+		domain.NewTrackMatch(mustSourceTrack("Bohemian Rhapsody", "Queen", "sp1"), ytBohemian, domain.MatchConfidenceHigh, "search"),
+		domain.NewTrackMatch(mustSourceTrack("Radio Ga Ga", "Queen", "sp2"), ytRadio, domain.MatchConfidenceHigh, "search"),
+	})
+
+	if err := f.converter.Convert(context.Background(), job); err != nil {
+		t.Fatalf("Convert() error: %v", err)
+	}
+
+	if !slices.Equal(youtubeClient.addedVideoIDs, []string{"yt-radio"}) {
+		t.Errorf("expected only the missing video to be added, got %v", youtubeClient.addedVideoIDs)
+	}
+	saved, _ := f.conversionRepo.Get(context.Background(), job.JobID)
+	if saved.Status != domain.ConversionStatusCompleted || !slices.Equal(saved.InsertedVideoIDs, []string{"yt-bohemian", "yt-radio"}) {
+		t.Errorf("status/inserted = %s/%v, want COMPLETED/[yt-bohemian yt-radio]", saved.Status, saved.InsertedVideoIDs)
+	}
+}
+
+func TestConverter_RecordsVideosInsertedBeforeFailure(t *testing.T) {
+	playlist, youtubeClient := resumeFixture()
+	youtubeClient.insertLimit = 1
+	youtubeClient.insertError = errors.New("youtube service returned status 429: quota exceeded")
+	f := newConverterFixture(playlist, youtubeClient)
+
+	job := domain.NewConversionJob("user", domain.PlatformSpotify, domain.PlatformYouTube, "playlist-1", "Converted")
+	if err := f.converter.Convert(context.Background(), job); err == nil {
+		t.Fatal("expected an error when inserts stop")
+	}
+
+	saved, _ := f.conversionRepo.Get(context.Background(), job.JobID)
+	if saved.Status != domain.ConversionStatusFailed || !slices.Equal(saved.InsertedVideoIDs, youtubeClient.addedVideoIDs) {
+		t.Errorf("status/inserted = %s/%v, want FAILED/%v", saved.Status, saved.InsertedVideoIDs, youtubeClient.addedVideoIDs)
+	}
+
+	youtubeClient.insertError = nil
+	if err := f.converter.Convert(context.Background(), job); err != nil {
+		t.Fatalf("retried Convert() error: %v", err)
+	}
+	if len(youtubeClient.addedVideoIDs) != 2 || youtubeClient.addedVideoIDs[0] == youtubeClient.addedVideoIDs[1] {
+		t.Errorf("expected each video to be added once, got %v", youtubeClient.addedVideoIDs)
+	}
+}
+
+func TestConverter_AbortsWhenTargetPlaylistIsNotStored(t *testing.T) {
+	playlist, youtubeClient := resumeFixture()
+	f := newConverterFixture(playlist, youtubeClient)
+	c := f.converter.(*converter)
+	c.conversionRepo = &playlistFailingConversionRepository{f.conversionRepo}
+
+	job := domain.NewConversionJob("user", domain.PlatformSpotify, domain.PlatformYouTube, "playlist-1", "Converted")
+	if err := f.converter.Convert(context.Background(), job); err == nil {
+		t.Error("expected an error when the target playlist cannot be stored")
+	}
+
+	if len(youtubeClient.addedVideoIDs) != 0 {
+		t.Errorf("expected no videos to be added, got %v", youtubeClient.addedVideoIDs)
+	}
+}
+
+func TestConverter_CompletesWithPartialInsertFailures(t *testing.T) {
+	playlist, youtubeClient := resumeFixture()
+	youtubeClient.rejectedVideos = map[string]string{"yt-radio": "video unavailable"}
+	f := newConverterFixture(playlist, youtubeClient)
+
+	job := domain.NewConversionJob("user", domain.PlatformSpotify, domain.PlatformYouTube, "playlist-1", "Converted")
+	if err := f.converter.Convert(context.Background(), job); err != nil {
+		t.Fatalf("Convert() error: %v", err)
+	}
+
+	conversion, _ := f.conversionRepo.Get(context.Background(), job.JobID)
+	if conversion.Status != domain.ConversionStatusCompleted || conversion.FailedInserts != 1 {
+		t.Errorf("status/failed inserts = %s/%d, want COMPLETED/1", conversion.Status, conversion.FailedInserts)
+	}
+
+	addLogs := f.logRepo.byStep(domain.StepAddTrackToPlaylist)
+	if len(addLogs) != 2 {
+		t.Fatalf("expected an add log per matched track, got %d", len(addLogs))
+	}
+	for _, l := range addLogs {
+		switch l.TargetTrackID {
+		case "yt-bohemian":
+			if l.Status != domain.LogStatusSuccess {
+				t.Errorf("expected yt-bohemian to be added, got %s", l.Status)
+			}
+		case "yt-radio":
+			if l.Status != domain.LogStatusFailed || l.ErrorMessage != "video unavailable" {
+				t.Errorf("expected yt-radio to fail with its reason, got %s %q", l.Status, l.ErrorMessage)
+			}
+		}
+	}
+}
+
+func TestConverter_FailsWhenNoVideoIsInserted(t *testing.T) {
+	playlist, youtubeClient := resumeFixture()
+	youtubeClient.rejectedVideos = map[string]string{"yt-bohemian": "video unavailable", "yt-radio": "video unavailable"}
+	f := newConverterFixture(playlist, youtubeClient)
+
+	job := domain.NewConversionJob("user", domain.PlatformSpotify, domain.PlatformYouTube, "playlist-1", "Converted")
+	if err := f.converter.Convert(context.Background(), job); err == nil {
+		t.Fatal("expected an error when every insert fails")
+	}
+
+	conversion, _ := f.conversionRepo.Get(context.Background(), job.JobID)
+	if conversion.Status != domain.ConversionStatusFailed || conversion.TargetPlaylistID != "playlist-id" {
+		t.Errorf("status/playlist = %s/%s, want FAILED/playlist-id", conversion.Status, conversion.TargetPlaylistID)
+	}
+}
+
+func mustSourceTrack(name, artist, id string) *domain.Track {
+	track, err := domain.NewTrack(name, artist, domain.PlatformSpotify, id)
+	if err != nil {
+		panic(err)
+	}
+	return track
+}
@@ -0,0 +1,28 @@
+package application
+
+import (
+	"context"
+	"errors"
+
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/domain"
+)
+
+type Enricher interface {
+	Enrich(ctx context.Context, track *domain.Track) error
+}
+
+type enricherChain []Enricher
+
+func ChainEnrichers(enrichers ...Enricher) Enricher {
+	return enricherChain(enrichers)
+}
+
+func (c enricherChain) Enrich(ctx context.Context, track *domain.Track) error {
+	var errs []error
+	for _, enricher := range c {
+		if err := enricher.Enrich(ctx, track); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -2,42 +2,171 @@ package application
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/marcelovmendes/playswap/conversion-worker/internal/domain"
 	"github.com/marcelovmendes/playswap/conversion-worker/internal/infrastructure/http"
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/infrastructure/redis"
 	"github.com/marcelovmendes/playswap/conversion-worker/internal/metrics"
 )
 
-var excludeTerms = []string{"cover", "live", "karaoke", "remix", "tutorial", "reaction"}
-var preferTerms = []string{"official", "audio", "video"}
+var defaultExcludeTerms = []string{"cover", "live", "karaoke", "remix", "tutorial", "reaction"}
+var defaultPreferTerms = []string{"official", "audio", "video"}
+
+const (
+	maxCandidates = 5
+	maxRejected   = 5
+)
+
+const (
+	isrcMatchMethod    = "isrc"
+	mappingMatchMethod = "mapping"
+	reviewMatchMethod  = domain.MatchMethodUserReview
+)
+
+var officialAudioTerms = []string{"official audio", "audio", "topic", "lyric"}
+var musicVideoTerms = []string{"official video", "music video", "official music video"}
 
 type Matcher interface {
-	MatchTracks(ctx context.Context, tracks []*domain.Track, sessionID string, concurrency int, onProgress func(processed, matched, failed int)) []*domain.TrackMatch
+	MatchTracks(ctx context.Context, tracks []*domain.Track, sessionID string, opts domain.MatchOptions, concurrency int, onProgress func(match *domain.TrackMatch, processed, matched, needsReview, failed int)) []*domain.TrackMatch
+}
+
+type matchRules struct {
+	excludeTerms       []string
+	preferTerms        []string
+	minConfidence      domain.MatchConfidence
+	videoPreference    domain.VideoPreference
+	explicitPreference domain.ExplicitPreference
+	versionPreference  domain.VersionPreference
+	market             string
+	weights            domain.ScoringWeights
+	channelLists       *domain.ChannelLists
+}
+
+func newMatchRules(opts domain.MatchOptions) *matchRules {
+	rules := &matchRules{
+		minConfidence:      opts.EffectiveMinConfidence(),
+		videoPreference:    opts.VideoPreference,
+		explicitPreference: opts.Explicit,
+		versionPreference:  opts.Version,
+		market:             opts.Market,
+	}
+
+	for _, term := range defaultExcludeTerms {
+		if (term == "live" && opts.AllowLive) || (term == "remix" && opts.AllowRemix) {
+			continue
+		}
+		rules.excludeTerms = append(rules.excludeTerms, term)
+	}
+	rules.excludeTerms = append(rules.excludeTerms, normalizeTerms(opts.ExcludeTerms)...)
+
+	rules.preferTerms = append(rules.preferTerms, defaultPreferTerms...)
+	rules.preferTerms = append(rules.preferTerms, normalizeTerms(opts.PreferTerms)...)
+
+	return rules
+}
+
+func normalizeTerms(terms []string) []string {
+	var normalized []string
+	for _, term := range terms {
+		term = strings.ToLower(strings.TrimSpace(term))
+		if term != "" {
+			normalized = append(normalized, term)
+		}
+	}
+	return normalized
 }
 
 type matcher struct {
-	youtubeClient http.YouTubeClient
+	youtubeClient  http.YouTubeClient
+	cache          redis.MatchCache
+	mappings       TrackMappingRepository
+	enricher       Enricher
+	strategies     []matchStrategy
+	weights        domain.ScoringWeights
+	channelEntries []*domain.ChannelListEntry
+	channelLists   ChannelListRepository
+}
+
+type MatcherOption func(*matcher)
+
+func WithMatchCache(cache redis.MatchCache) MatcherOption {
+	return func(m *matcher) {
+		m.cache = cache
+	}
+}
+
+func WithTrackMappings(mappings TrackMappingRepository) MatcherOption {
+	return func(m *matcher) {
+		m.mappings = mappings
+	}
+}
+
+func WithStrategies(names []string) MatcherOption {
+	return func(m *matcher) {
+		m.strategies = buildStrategies(m, names)
+	}
+}
+
+// WithScoringWeights ranks candidates with learned feature weights instead of the plain score total.
+func WithScoringWeights(weights domain.ScoringWeights) MatcherOption {
+	return func(m *matcher) {
+		m.weights = weights
+	}
 }
 
-func NewMatcher(youtubeClient http.YouTubeClient) Matcher {
-	return &matcher{youtubeClient: youtubeClient}
+// WithChannelLists trusts or blocks channels and videos. Entries passed here apply to every job;
+// the repository, when set, adds global and per-user entries at the start of each job.
+func WithChannelLists(entries []*domain.ChannelListEntry, repo ChannelListRepository) MatcherOption {
+	return func(m *matcher) {
+		m.channelEntries = entries
+		m.channelLists = repo
+	}
 }
 
-func (m *matcher) MatchTracks(ctx context.Context, tracks []*domain.Track, sessionID string, concurrency int, onProgress func(processed, matched, failed int)) []*domain.TrackMatch {
+func WithEnricher(enricher Enricher) MatcherOption {
+	return func(m *matcher) {
+		m.enricher = enricher
+	}
+}
+
+func NewMatcher(youtubeClient http.YouTubeClient, opts ...MatcherOption) Matcher {
+	m := &matcher{youtubeClient: youtubeClient}
+	for _, opt := range opts {
+		opt(m)
+	}
+	if m.strategies == nil {
+		m.strategies = buildStrategies(m, DefaultStrategies)
+	}
+	return m
+}
+
+func (m *matcher) MatchTracks(ctx context.Context, tracks []*domain.Track, sessionID string, opts domain.MatchOptions, concurrency int, onProgress func(match *domain.TrackMatch, processed, matched, needsReview, failed int)) []*domain.TrackMatch {
 	if len(tracks) == 0 {
 		return nil
 	}
 
-	results := make(chan *domain.TrackMatch, len(tracks))
+	rules := newMatchRules(opts)
+	rules.weights = m.weights
+	rules.channelLists = m.loadChannelLists(ctx, sessionID)
+
+	type indexedMatch struct {
+		index int
+		match *domain.TrackMatch
+	}
+
+	results := make(chan indexedMatch, len(tracks))
 	sem := make(chan struct{}, concurrency)
 	var wg sync.WaitGroup
 
-	for _, track := range tracks {
+	for i, track := range tracks {
 		wg.Add(1)
-		go func(t *domain.Track) {
+		go func(i int, t *domain.Track) {
 			defer wg.Done()
 
 			sem <- struct{}{}
@@ -45,14 +174,13 @@ func (m *matcher) MatchTracks(ctx context.Context, tracks []*domain.Track, sessi
 
 			select {
 			case <-ctx.Done():
-				results <- domain.NewFailedMatch(t, "context cancelled")
+				results <- indexedMatch{i, domain.NewFailedMatch(t, "context cancelled")}
 				return
 			default:
 			}
 
-			match := m.matchTrack(ctx, t, sessionID)
-			results <- match
-		}(track)
+			results <- indexedMatch{i, m.matchTrack(ctx, t, sessionID, rules)}
+		}(i, track)
 	}
 
 	go func() {
@@ -60,75 +188,221 @@ func (m *matcher) MatchTracks(ctx context.Context, tracks []*domain.Track, sessi
 		close(results)
 	}()
 
-	var matches []*domain.TrackMatch
-	processed, matched, failed := 0, 0, 0
+	// Results arrive in completion order; slotting them by index keeps the source playlist order.
+	matches := make([]*domain.TrackMatch, len(tracks))
+	processed, matched, needsReview, failed := 0, 0, 0, 0
 
-	for match := range results {
-		matches = append(matches, match)
+	for result := range results {
+		match := result.match
+		matches[result.index] = match
 		processed++
 
-		if match.Confidence != domain.MatchConfidenceNone {
+		switch {
+		case match.NeedsReview:
+			needsReview++
+			metrics.TracksNeedsReview.Inc()
+		case match.Confidence != domain.MatchConfidenceNone:
 			matched++
 			metrics.TracksMatched.Inc()
-		} else {
+		default:
 			failed++
 			metrics.TracksNotFound.Inc()
 		}
 
 		if onProgress != nil {
-			onProgress(processed, matched, failed)
+			onProgress(match, processed, matched, needsReview, failed)
 		}
 	}
 
 	return matches
 }
 
-func (m *matcher) matchTrack(ctx context.Context, sourceTrack *domain.Track, sessionID string) *domain.TrackMatch {
-	if match := m.tryISRCSearch(ctx, sourceTrack, sessionID); match != nil {
-		return match
+func (m *matcher) matchTrack(ctx context.Context, sourceTrack *domain.Track, sessionID string, rules *matchRules) *domain.TrackMatch {
+	m.enrich(ctx, sourceTrack)
+
+	var match *domain.TrackMatch
+	var searchErr error
+	for _, strategy := range m.strategies {
+		start := time.Now()
+		var err error
+		match, err = strategy.Match(ctx, sourceTrack, sessionID, rules)
+		metrics.MatchStrategyDuration.WithLabelValues(strategy.Name()).Observe(time.Since(start).Seconds())
+
+		if match != nil {
+			metrics.MatchStrategyResults.WithLabelValues(strategy.Name(), "matched").Inc()
+			break
+		}
+		if err != nil {
+			metrics.MatchStrategyResults.WithLabelValues(strategy.Name(), "error").Inc()
+			if searchErr == nil {
+				searchErr = err
+			}
+			continue
+		}
+		metrics.MatchStrategyResults.WithLabelValues(strategy.Name(), "miss").Inc()
+	}
+
+	if match == nil {
+		if searchErr != nil {
+			return domain.NewSearchFailedMatch(sourceTrack, searchErr)
+		}
+		return domain.NewFailedMatch(sourceTrack, "no match found")
+	}
+
+	if !match.Confidence.AtLeast(rules.minConfidence) {
+		match.MarkNeedsReview(fmt.Sprintf("match confidence %s is below minimum %s", match.Confidence, rules.minConfidence))
+	}
+
+	return match
+}
+
+func (m *matcher) enrich(ctx context.Context, track *domain.Track) {
+	if m.enricher == nil {
+		return
+	}
+
+	if err := m.enricher.Enrich(ctx, track); err != nil {
+		log.Printf("[DEBUG] enrichment failed for %q: %v", track.Name, err)
+	}
+}
+
+func (m *matcher) tryMapping(ctx context.Context, sourceTrack *domain.Track, rules *matchRules) (*domain.TrackMatch, error) {
+	if m.mappings == nil {
+		return nil, nil
+	}
+
+	mapping, err := m.mappings.FindBest(ctx, sourceTrack.Platform, sourceTrack.PlatformID, domain.PlatformYouTube)
+	if err != nil {
+		log.Printf("[DEBUG] mapping lookup failed for %q: %v", sourceTrack.Name, err)
+		return nil, fmt.Errorf("mapping lookup failed: %w", err)
+	}
+	if mapping == nil || !mapping.IsConfirmed() {
+		return nil, nil
+	}
+
+	targetTrack := mapping.TargetTrack()
+	reason := rules.candidateRejectReason(targetTrack)
+	if reason == "" {
+		reason = rules.preferenceConflict(sourceTrack, targetTrack)
+	}
+	if reason != "" {
+		log.Printf("[DEBUG] mapping for %q skipped: %s", sourceTrack.Name, reason)
+		return nil, nil
+	}
+
+	match := domain.NewTrackMatch(sourceTrack, targetTrack, domain.MatchConfidenceHigh, mappingMatchMethod)
+	match.Candidates = []*domain.Track{targetTrack}
+	return match, nil
+}
+
+// tryCache answers from cached lookups only, without calling YouTube. A cached ISRC hit wins;
+// cached search results are only used once the ISRC is known to have no video. A failed cache read
+// counts as a miss, since the later strategies search YouTube anyway.
+func (m *matcher) tryCache(ctx context.Context, sourceTrack *domain.Track, rules *matchRules) (*domain.TrackMatch, error) {
+	if m.cache == nil {
+		return nil, nil
 	}
 
-	if match := m.tryMusicSearch(ctx, sourceTrack, sessionID); match != nil {
-		return match
+	if sourceTrack.ISRC != "" {
+		targetTrack, found, err := m.cache.GetISRC(ctx, marketKey(sourceTrack.ISRC, rules.market))
+		if err != nil || !found {
+			return nil, nil
+		}
+		if targetTrack != nil && rules.unavailableReason(targetTrack) == "" && rules.blockReason(targetTrack) == "" {
+			match := domain.NewTrackMatch(sourceTrack, targetTrack, domain.MatchConfidenceHigh, isrcMatchMethod)
+			match.Candidates = []*domain.Track{targetTrack}
+			return match, nil
+		}
 	}
 
-	return domain.NewFailedMatch(sourceTrack, "no match found")
+	tracks, found, err := m.cache.GetSearch(ctx, marketKey(sourceTrack.Fingerprint(), rules.market))
+	if err != nil || !found || len(tracks) == 0 {
+		return nil, nil
+	}
+	return rankCandidates(sourceTrack, tracks, rules), nil
 }
 
-func (m *matcher) tryISRCSearch(ctx context.Context, sourceTrack *domain.Track, sessionID string) *domain.TrackMatch {
+func (m *matcher) tryISRCSearch(ctx context.Context, sourceTrack *domain.Track, sessionID string, rules *matchRules) (*domain.TrackMatch, error) {
 	if sourceTrack.ISRC == "" {
-		return nil
+		return nil, nil
 	}
 
-	targetTrack, err := m.youtubeClient.SearchByISRC(ctx, sourceTrack.ISRC, sessionID)
+	targetTrack, err := m.searchByISRC(ctx, sourceTrack.ISRC, rules.market, sessionID)
 	if err != nil {
 		log.Printf("[DEBUG] ISRC search failed for %q: %v", sourceTrack.Name, err)
-		return nil
+		return nil, fmt.Errorf("ISRC search failed: %w", err)
 	}
 	if targetTrack == nil {
-		return nil
+		return nil, nil
+	}
+	if reason := rules.unavailableReason(targetTrack); reason != "" {
+		log.Printf("[DEBUG] ISRC result for %q skipped: %s", sourceTrack.Name, reason)
+		return nil, nil
+	}
+	if reason := rules.blockReason(targetTrack); reason != "" {
+		log.Printf("[DEBUG] ISRC result for %q skipped: %s", sourceTrack.Name, reason)
+		return nil, nil
 	}
 
-	return domain.NewTrackMatch(sourceTrack, targetTrack, domain.MatchConfidenceHigh, "isrc")
+	match := domain.NewTrackMatch(sourceTrack, targetTrack, domain.MatchConfidenceHigh, isrcMatchMethod)
+	match.Candidates = []*domain.Track{targetTrack}
+	return match, nil
 }
 
-func (m *matcher) tryMusicSearch(ctx context.Context, sourceTrack *domain.Track, sessionID string) *domain.TrackMatch {
+func (m *matcher) tryMusicSearch(ctx context.Context, sourceTrack *domain.Track, sessionID string, rules *matchRules) (*domain.TrackMatch, error) {
 	log.Printf("[DEBUG] searching YouTube for track=%q artist=%q", sourceTrack.Name, sourceTrack.Artist)
 
-	tracks, err := m.youtubeClient.SearchTrack(ctx, sourceTrack.Name, sourceTrack.Artist, sessionID)
+	query := searchQuery{track: sourceTrack.Name, artist: searchArtist(sourceTrack)}
+	tracks, err := m.searchTrack(ctx, sourceTrack, query, rules.market, sessionID)
 	if err != nil {
 		log.Printf("[DEBUG] music search failed for %q - %q: %v", sourceTrack.Artist, sourceTrack.Name, err)
-		return nil
+		return nil, fmt.Errorf("music search failed: %w", err)
 	}
 	if len(tracks) == 0 {
 		log.Printf("[DEBUG] music search returned 0 results for %q - %q", sourceTrack.Artist, sourceTrack.Name)
-		return nil
+		return nil, nil
 	}
 
 	log.Printf("[DEBUG] music search returned %d results", len(tracks))
 
+	return rankCandidates(sourceTrack, tracks, rules), nil
+}
+
+// tryFallbackSearches keeps going after a failed query, since a later one may still find a match.
+func (m *matcher) tryFallbackSearches(ctx context.Context, sourceTrack *domain.Track, sessionID string, rules *matchRules) (*domain.TrackMatch, error) {
+	var searchErr error
+	for _, query := range fallbackQueries(sourceTrack) {
+		log.Printf("[DEBUG] fallback search %s for track=%q artist=%q", query.variant, query.track, query.artist)
+
+		tracks, err := m.searchTrack(ctx, sourceTrack, query, rules.market, sessionID)
+		if err != nil {
+			log.Printf("[DEBUG] fallback search %s failed for %q: %v", query.variant, sourceTrack.Name, err)
+			if searchErr == nil {
+				searchErr = fmt.Errorf("fallback search %s failed: %w", query.variant, err)
+			}
+			continue
+		}
+
+		match := rankCandidates(sourceTrack, tracks, rules)
+		if match == nil || !match.Confidence.AtLeast(domain.MatchConfidenceMedium) {
+			continue
+		}
+
+		match.MatchMethod = match.MatchMethod + ":" + query.variant
+		return match, nil
+	}
+
+	return nil, searchErr
+}
+
+func rankCandidates(sourceTrack *domain.Track, tracks []*domain.Track, rules *matchRules) *domain.TrackMatch {
+	var ranked []*rankedCandidate
+	var excluded []domain.RejectedCandidate
+	work, classical := domain.ParseClassicalTitle(sourceTrack.Name)
+
 	for _, targetTrack := range tracks {
-		if isExcluded(targetTrack.Name) {
+		if reason := rules.candidateRejectReason(targetTrack); reason != "" {
+			excluded = append(excluded, domain.NewRejectedCandidate(targetTrack, 0, reason))
 			continue
 		}
 
@@ -143,16 +417,212 @@ func (m *matcher) tryMusicSearch(ctx context.Context, sourceTrack *domain.Track,
 			method = "partial_match"
 		}
 
-		return domain.NewTrackMatch(sourceTrack, targetTrack, confidence, method)
+		if classical {
+			if c := classicalConfidence(sourceTrack, work, targetTrack); c.Rank() > confidence.Rank() {
+				confidence = c
+				method = classicalMatchMethod
+			}
+		}
+
+		breakdown := rules.scoreFeatures(sourceTrack, targetTrack, confidence)
+		ranked = append(ranked, &rankedCandidate{
+			track:      targetTrack,
+			confidence: confidence,
+			method:     method,
+			breakdown:  breakdown,
+			score:      rules.weights.Score(breakdown),
+		})
+	}
+
+	if len(ranked) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].confidence != ranked[j].confidence {
+			return ranked[i].confidence.Rank() > ranked[j].confidence.Rank()
+		}
+		return ranked[i].score > ranked[j].score
+	})
+
+	best := ranked[0]
+	match := domain.NewTrackMatch(sourceTrack, best.track, best.confidence, best.method)
+	match.ScoreBreakdown = best.breakdown
+	for _, candidate := range ranked {
+		if len(match.Candidates) == maxCandidates {
+			break
+		}
+		match.Candidates = append(match.Candidates, candidate.track)
+	}
+
+	for _, candidate := range ranked[1:] {
+		if len(match.Rejected) == maxRejected {
+			break
+		}
+		reason := fmt.Sprintf("scored %g, below selected %g", candidate.score, best.score)
+		if candidate.confidence != best.confidence {
+			reason = fmt.Sprintf("confidence %s below selected %s", candidate.confidence, best.confidence)
+		}
+		rejected := domain.NewRejectedCandidate(candidate.track, candidate.score, reason)
+		rejected.Breakdown = candidate.breakdown
+		match.Rejected = append(match.Rejected, rejected)
+	}
+	for _, candidate := range excluded {
+		if len(match.Rejected) == maxRejected {
+			break
+		}
+		match.Rejected = append(match.Rejected, candidate)
+	}
+
+	return match
+}
+
+func (m *matcher) searchByISRC(ctx context.Context, isrc, market, sessionID string) (*domain.Track, error) {
+	key := marketKey(isrc, market)
+
+	if m.cache != nil {
+		track, found, err := m.cache.GetISRC(ctx, key)
+		if err != nil {
+			log.Printf("match cache lookup failed for ISRC %s: %v", isrc, err)
+		} else if found {
+			recordCacheHit("isrc", track != nil)
+			return track, nil
+		} else {
+			metrics.MatchCacheMisses.WithLabelValues("isrc").Inc()
+		}
+	}
+
+	track, err := m.youtubeClient.SearchByISRC(ctx, isrc, market, sessionID)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	if m.cache != nil {
+		if err := m.cache.SetISRC(ctx, key, track); err != nil {
+			log.Printf("failed to cache ISRC result for %s: %v", isrc, err)
+		}
+	}
+
+	return track, nil
 }
 
-func isExcluded(title string) bool {
-	titleLower := strings.ToLower(title)
-	for _, term := range excludeTerms {
-		if strings.Contains(titleLower, term) {
+func (m *matcher) searchTrack(ctx context.Context, sourceTrack *domain.Track, query searchQuery, market, sessionID string) ([]*domain.Track, error) {
+	fingerprint := sourceTrack.Fingerprint()
+	if query.variant != "" {
+		fingerprint += "#" + query.variant
+	}
+	fingerprint = marketKey(fingerprint, market)
+
+	if m.cache != nil {
+		tracks, found, err := m.cache.GetSearch(ctx, fingerprint)
+		if err != nil {
+			log.Printf("match cache lookup failed for %q: %v", fingerprint, err)
+		} else if found {
+			recordCacheHit("search", len(tracks) > 0)
+			return tracks, nil
+		} else {
+			metrics.MatchCacheMisses.WithLabelValues("search").Inc()
+		}
+	}
+
+	tracks, err := m.youtubeClient.SearchTrack(ctx, query.track, query.artist, market, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if m.cache != nil {
+		if err := m.cache.SetSearch(ctx, fingerprint, tracks); err != nil {
+			log.Printf("failed to cache search result for %q: %v", fingerprint, err)
+		}
+	}
+
+	return tracks, nil
+}
+
+func marketKey(key, market string) string {
+	if market == "" {
+		return key
+	}
+	return key + "@" + market
+}
+
+func recordCacheHit(kind string, positive bool) {
+	result := "positive"
+	if !positive {
+		result = "negative"
+	}
+	metrics.MatchCacheHits.WithLabelValues(kind, result).Inc()
+}
+
+type rankedCandidate struct {
+	track      *domain.Track
+	confidence domain.MatchConfidence
+	method     string
+	breakdown  domain.ScoreBreakdown
+	score      float64
+}
+
+// candidateRejectReason explains why a candidate may not be picked at all, or returns "" if it may.
+func (r *matchRules) candidateRejectReason(target *domain.Track) string {
+	if term := r.excludedTerm(target.Name); term != "" {
+		return fmt.Sprintf("title contains excluded term %q", term)
+	}
+	if reason := r.unavailableReason(target); reason != "" {
+		return reason
+	}
+	return r.blockReason(target)
+}
+
+// preferenceConflict explains why a target picked without ranking goes against the explicit or
+// version preference. Ranked candidates only lose points for this instead.
+func (r *matchRules) preferenceConflict(source, target *domain.Track) string {
+	if r.explicitScore(source, target) < 0 {
+		return "video does not match the explicit preference"
+	}
+	if r.versionScore(source, target) < 0 {
+		return "video does not match the version preference"
+	}
+	return ""
+}
+
+func (r *matchRules) unavailableReason(target *domain.Track) string {
+	if !target.Availability.IsEmbeddable() {
+		metrics.CandidatesUnavailable.WithLabelValues("not_embeddable").Inc()
+		return "video cannot be embedded"
+	}
+	if !target.Availability.AvailableIn(r.market) {
+		metrics.CandidatesUnavailable.WithLabelValues("region_blocked").Inc()
+		return fmt.Sprintf("video is not available in %s", r.market)
+	}
+	return ""
+}
+
+func (r *matchRules) blockReason(target *domain.Track) string {
+	reason := r.channelLists.BlockReason(target)
+	if reason != "" {
+		metrics.ChannelListHits.WithLabelValues("blocked").Inc()
+	}
+	return reason
+}
+
+func (r *matchRules) isExcluded(title string) bool {
+	return r.excludedTerm(title) != ""
+}
+
+func (r *matchRules) excludedTerm(title string) string {
+	lower := strings.ToLower(title)
+	for _, term := range r.excludeTerms {
+		if strings.Contains(lower, term) {
+			return term
+		}
+	}
+	return ""
+}
+
+func containsAny(s string, terms []string) bool {
+	lower := strings.ToLower(s)
+	for _, term := range terms {
+		if strings.Contains(lower, term) {
 			return true
 		}
 	}
@@ -160,12 +630,19 @@ func isExcluded(title string) bool {
 }
 
 func hasArtistMatch(source, target *domain.Track) bool {
-	artistLower := strings.ToLower(source.Artist)
 	targetArtistLower := strings.ToLower(target.Artist)
 	titleLower := strings.ToLower(target.Name)
 
-	return strings.Contains(targetArtistLower, artistLower) ||
-		strings.Contains(titleLower, artistLower)
+	for _, artist := range matchArtists(source) {
+		artistLower := strings.ToLower(artist)
+		if artistLower == "" {
+			continue
+		}
+		if strings.Contains(targetArtistLower, artistLower) || strings.Contains(titleLower, artistLower) {
+			return true
+		}
+	}
+	return false
 }
 
 func hasTitleMatch(source, target *domain.Track) bool {
@@ -174,13 +651,3 @@ func hasTitleMatch(source, target *domain.Track) bool {
 
 	return strings.Contains(targetTitleLower, sourceTitleLower)
 }
-
-func hasPreferredTerm(title string) bool {
-	titleLower := strings.ToLower(title)
-	for _, term := range preferTerms {
-		if strings.Contains(titleLower, term) {
-			return true
-		}
-	}
-	return false
-}
@@ -3,37 +3,98 @@ package application
 import (
 	"context"
 	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/marcelovmendes/playswap/conversion-worker/internal/domain"
 )
 
 type mockYouTubeClient struct {
-	isrcResults  map[string]*domain.Track
-	trackResults map[string][]*domain.Track
-	searchError  error
+	isrcResults      map[string]*domain.Track
+	trackResults     map[string][]*domain.Track
+	searchError      error
+	trackErrors      map[string]error
+	addedVideoIDs    []string
+	replacedVideoIDs []string
+	searchCalls      atomic.Int32
+	mu               sync.Mutex
+	lastMarket       string
+	searchDelay      func(track string) time.Duration
+	createdPlaylists int
+	onAddVideos      func()
+	rejectedVideos   map[string]string
+	// insertLimit stops inserts with insertError after that many videos, like a rate limit mid-playlist.
+	insertLimit int
+	insertError error
 }
 
-func (m *mockYouTubeClient) SearchByISRC(ctx context.Context, isrc, sessionID string) (*domain.Track, error) {
+func (m *mockYouTubeClient) SearchByISRC(ctx context.Context, isrc, market, sessionID string) (*domain.Track, error) {
+	m.searchCalls.Add(1)
 	if m.searchError != nil {
 		return nil, m.searchError
 	}
 	return m.isrcResults[isrc], nil
 }
 
-func (m *mockYouTubeClient) SearchTrack(ctx context.Context, track, artist, sessionID string) ([]*domain.Track, error) {
+func (m *mockYouTubeClient) SearchTrack(ctx context.Context, track, artist, market, sessionID string) ([]*domain.Track, error) {
+	m.searchCalls.Add(1)
+	m.mu.Lock()
+	m.lastMarket = market
+	m.mu.Unlock()
+	if m.searchDelay != nil {
+		time.Sleep(m.searchDelay(track))
+	}
 	if m.searchError != nil {
 		return nil, m.searchError
 	}
+	if err := m.trackErrors[track]; err != nil {
+		return nil, err
+	}
 	key := track + "|" + artist
 	return m.trackResults[key], nil
 }
 
+func (m *mockYouTubeClient) market() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.lastMarket
+}
+
 func (m *mockYouTubeClient) CreatePlaylist(ctx context.Context, name, description, sessionID string) (string, string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.createdPlaylists++
 	return "playlist-id", "https://youtube.com/playlist?list=xxx", nil
 }
 
-func (m *mockYouTubeClient) AddVideosToPlaylist(ctx context.Context, playlistID string, videoIDs []string, sessionID string) error {
+func (m *mockYouTubeClient) AddVideosToPlaylist(ctx context.Context, playlistID string, videoIDs []string, sessionID string) ([]domain.VideoInsertResult, error) {
+	if m.onAddVideos != nil {
+		m.onAddVideos()
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	results := make([]domain.VideoInsertResult, 0, len(videoIDs))
+	for i, videoID := range videoIDs {
+		if m.insertError != nil && i == m.insertLimit {
+			return results, m.insertError
+		}
+		if reason, ok := m.rejectedVideos[videoID]; ok {
+			results = append(results, domain.VideoInsertResult{VideoID: videoID, Error: reason})
+			continue
+		}
+		m.addedVideoIDs = append(m.addedVideoIDs, videoID)
+		results = append(results, domain.VideoInsertResult{VideoID: videoID})
+	}
+	return results, nil
+}
+
+func (m *mockYouTubeClient) ReplaceVideoInPlaylist(ctx context.Context, playlistID, oldVideoID, newVideoID, sessionID string) error {
+	m.replacedVideoIDs = append(m.replacedVideoIDs, oldVideoID+"->"+newVideoID)
 	return nil
 }
 
@@ -51,7 +112,7 @@ func TestMatcher_ISRC(t *testing.T) {
 	sourceTrack.WithISRC("GBUM71029604")
 
 	matcher := NewMatcher(mockClient)
-	matches := matcher.MatchTracks(context.Background(), []*domain.Track{sourceTrack}, "session", 1, nil)
+	matches := matcher.MatchTracks(context.Background(), []*domain.Track{sourceTrack}, "session", domain.MatchOptions{}, 1, nil)
 
 	if len(matches) != 1 {
 		t.Fatalf("expected 1 match, got %d", len(matches))
@@ -79,7 +140,7 @@ func TestMatcher_ExactMatch(t *testing.T) {
 	sourceTrack, _ := domain.NewTrack("Bohemian Rhapsody", "Queen", domain.PlatformSpotify, "sp1")
 
 	matcher := NewMatcher(mockClient)
-	matches := matcher.MatchTracks(context.Background(), []*domain.Track{sourceTrack}, "session", 1, nil)
+	matches := matcher.MatchTracks(context.Background(), []*domain.Track{sourceTrack}, "session", domain.MatchOptions{}, 1, nil)
 
 	if len(matches) != 1 {
 		t.Fatalf("expected 1 match, got %d", len(matches))
@@ -107,7 +168,7 @@ func TestMatcher_PartialMatch(t *testing.T) {
 	sourceTrack, _ := domain.NewTrack("Bohemian Rhapsody", "Queen", domain.PlatformSpotify, "sp1")
 
 	matcher := NewMatcher(mockClient)
-	matches := matcher.MatchTracks(context.Background(), []*domain.Track{sourceTrack}, "session", 1, nil)
+	matches := matcher.MatchTracks(context.Background(), []*domain.Track{sourceTrack}, "session", domain.MatchOptions{}, 1, nil)
 
 	if len(matches) != 1 {
 		t.Fatalf("expected 1 match, got %d", len(matches))
@@ -135,7 +196,7 @@ func TestMatcher_LowConfidence(t *testing.T) {
 	sourceTrack, _ := domain.NewTrack("Bohemian Rhapsody", "Queen", domain.PlatformSpotify, "sp1")
 
 	matcher := NewMatcher(mockClient)
-	matches := matcher.MatchTracks(context.Background(), []*domain.Track{sourceTrack}, "session", 1, nil)
+	matches := matcher.MatchTracks(context.Background(), []*domain.Track{sourceTrack}, "session", domain.MatchOptions{}, 1, nil)
 
 	if len(matches) != 1 {
 		t.Fatalf("expected 1 match, got %d", len(matches))
@@ -159,12 +220,15 @@ func TestMatcher_NoResults(t *testing.T) {
 	sourceTrack, _ := domain.NewTrack("Unknown Song", "Unknown Artist", domain.PlatformSpotify, "sp1")
 
 	matcher := NewMatcher(mockClient)
-	matches := matcher.MatchTracks(context.Background(), []*domain.Track{sourceTrack}, "session", 1, nil)
+	matches := matcher.MatchTracks(context.Background(), []*domain.Track{sourceTrack}, "session", domain.MatchOptions{}, 1, nil)
 
 	if len(matches) != 1 {
 		t.Fatalf("expected 1 match, got %d", len(matches))
 	}
 
+	if matches[0].SearchFailed {
+		t.Error("expected an empty search to be a confirmed miss")
+	}
 	if matches[0].Confidence != domain.MatchConfidenceNone {
 		t.Errorf("expected NONE confidence, got %v", matches[0].Confidence)
 	}
@@ -187,7 +251,7 @@ func TestMatcher_ExcludesCovers(t *testing.T) {
 	sourceTrack, _ := domain.NewTrack("Bohemian Rhapsody", "Queen", domain.PlatformSpotify, "sp1")
 
 	matcher := NewMatcher(mockClient)
-	matches := matcher.MatchTracks(context.Background(), []*domain.Track{sourceTrack}, "session", 1, nil)
+	matches := matcher.MatchTracks(context.Background(), []*domain.Track{sourceTrack}, "session", domain.MatchOptions{}, 1, nil)
 
 	if len(matches) != 1 {
 		t.Fatalf("expected 1 match, got %d", len(matches))
@@ -211,72 +275,1132 @@ func TestMatcher_ExcludesLive(t *testing.T) {
 	sourceTrack, _ := domain.NewTrack("Bohemian Rhapsody", "Queen", domain.PlatformSpotify, "sp1")
 
 	matcher := NewMatcher(mockClient)
-	matches := matcher.MatchTracks(context.Background(), []*domain.Track{sourceTrack}, "session", 1, nil)
+	matches := matcher.MatchTracks(context.Background(), []*domain.Track{sourceTrack}, "session", domain.MatchOptions{}, 1, nil)
 
 	if matches[0].Confidence != domain.MatchConfidenceNone {
 		t.Errorf("expected NONE confidence (live excluded), got %v", matches[0].Confidence)
 	}
 }
 
-func TestMatcher_EmptyTracks(t *testing.T) {
-	mockClient := &mockYouTubeClient{}
+func TestMatcher_AllowLiveOption(t *testing.T) {
+	ytLive, _ := domain.NewTrack("Bohemian Rhapsody Live at Wembley", "Queen", domain.PlatformYouTube, "yt1")
+
+	mockClient := &mockYouTubeClient{
+		isrcResults: map[string]*domain.Track{},
+		trackResults: map[string][]*domain.Track{
+			"Bohemian Rhapsody|Queen": {ytLive},
+		},
+	}
+
+	sourceTrack, _ := domain.NewTrack("Bohemian Rhapsody", "Queen", domain.PlatformSpotify, "sp1")
+
 	matcher := NewMatcher(mockClient)
+	matches := matcher.MatchTracks(context.Background(), []*domain.Track{sourceTrack}, "session", domain.MatchOptions{AllowLive: true}, 1, nil)
 
-	matches := matcher.MatchTracks(context.Background(), nil, "session", 1, nil)
-	if matches != nil {
-		t.Errorf("expected nil for nil tracks, got %v", matches)
+	if matches[0].Confidence != domain.MatchConfidenceHigh {
+		t.Errorf("expected HIGH confidence (live allowed), got %v", matches[0].Confidence)
 	}
+}
 
-	matches = matcher.MatchTracks(context.Background(), []*domain.Track{}, "session", 1, nil)
-	if matches != nil {
-		t.Errorf("expected nil for empty slice, got %v", matches)
+func TestMatcher_ExtraExcludeTerms(t *testing.T) {
+	ytTrack, _ := domain.NewTrack("Queen - Bohemian Rhapsody (Slowed)", "Queen", domain.PlatformYouTube, "yt1")
+
+	mockClient := &mockYouTubeClient{
+		isrcResults: map[string]*domain.Track{},
+		trackResults: map[string][]*domain.Track{
+			"Bohemian Rhapsody|Queen": {ytTrack},
+		},
+	}
+
+	sourceTrack, _ := domain.NewTrack("Bohemian Rhapsody", "Queen", domain.PlatformSpotify, "sp1")
+
+	matcher := NewMatcher(mockClient)
+	matches := matcher.MatchTracks(context.Background(), []*domain.Track{sourceTrack}, "session", domain.MatchOptions{ExcludeTerms: []string{" Slowed "}}, 1, nil)
+
+	if matches[0].Confidence != domain.MatchConfidenceNone {
+		t.Errorf("expected NONE confidence (extra term excluded), got %v", matches[0].Confidence)
 	}
 }
 
-func TestMatcher_ProgressCallback(t *testing.T) {
-	ytTrack, _ := domain.NewTrack("Track 1", "Artist", domain.PlatformYouTube, "yt1")
+func TestMatcher_MinConfidenceOption(t *testing.T) {
+	ytTrack, _ := domain.NewTrack("Some Music Video", "RandomChannel", domain.PlatformYouTube, "yt1")
 
 	mockClient := &mockYouTubeClient{
 		isrcResults: map[string]*domain.Track{},
 		trackResults: map[string][]*domain.Track{
-			"Track 1|Artist": {ytTrack},
-			"Track 2|Artist": {ytTrack},
+			"Bohemian Rhapsody|Queen": {ytTrack},
 		},
 	}
 
-	track1, _ := domain.NewTrack("Track 1", "Artist", domain.PlatformSpotify, "sp1")
-	track2, _ := domain.NewTrack("Track 2", "Artist", domain.PlatformSpotify, "sp2")
+	sourceTrack, _ := domain.NewTrack("Bohemian Rhapsody", "Queen", domain.PlatformSpotify, "sp1")
 
 	matcher := NewMatcher(mockClient)
+	matches := matcher.MatchTracks(context.Background(), []*domain.Track{sourceTrack}, "session", domain.MatchOptions{MinConfidence: domain.MatchConfidenceMedium}, 1, nil)
 
-	var progressCalls int
-	matches := matcher.MatchTracks(context.Background(), []*domain.Track{track1, track2}, "session", 2, func(processed, matched, failed int) {
-		progressCalls++
+	if !matches[0].NeedsReview {
+		t.Error("expected match below minimum confidence to need review")
+	}
+	if matches[0].IsMatched() {
+		t.Error("expected match below minimum confidence not to count as matched")
+	}
+	if matches[0].TargetTrack == nil || matches[0].TargetTrack.PlatformID != "yt1" {
+		t.Error("expected the candidate to be kept for review")
+	}
+	if matches[0].Error == "" {
+		t.Error("expected review reason for match below minimum confidence")
+	}
+}
+
+func TestMatcher_ProgressCountsNeedsReview(t *testing.T) {
+	ytExact, _ := domain.NewTrack("Queen - Bohemian Rhapsody", "Queen", domain.PlatformYouTube, "yt1")
+	ytUnrelated, _ := domain.NewTrack("Some Music Video", "RandomChannel", domain.PlatformYouTube, "yt2")
+
+	mockClient := &mockYouTubeClient{
+		isrcResults: map[string]*domain.Track{},
+		trackResults: map[string][]*domain.Track{
+			"Bohemian Rhapsody|Queen": {ytExact},
+			"Radio Ga Ga|Queen":       {ytUnrelated},
+		},
+	}
+
+	tracks := []*domain.Track{
+		mustTrack("Bohemian Rhapsody", "Queen"),
+		mustTrack("Radio Ga Ga", "Queen"),
+		mustTrack("Unknown Song", "Queen"),
+	}
+
+	matcher := NewMatcher(mockClient)
+
+	var gotMatched, gotReview, gotFailed int
+	matcher.MatchTracks(context.Background(), tracks, "session", domain.MatchOptions{MinConfidence: domain.MatchConfidenceMedium}, 1, func(match *domain.TrackMatch, processed, matched, needsReview, failed int) {
+		gotMatched, gotReview, gotFailed = matched, needsReview, failed
 	})
 
-	if len(matches) != 2 {
-		t.Errorf("expected 2 matches, got %d", len(matches))
+	if gotMatched != 1 || gotReview != 1 || gotFailed != 1 {
+		t.Errorf("progress = (matched %d, review %d, failed %d), want (1, 1, 1)", gotMatched, gotReview, gotFailed)
 	}
+}
 
-	if progressCalls != 2 {
-		t.Errorf("expected 2 progress calls, got %d", progressCalls)
+func TestMatcher_VideoPreference(t *testing.T) {
+	ytVideo, _ := domain.NewTrack("Queen - Bohemian Rhapsody (Official Video)", "Queen Official", domain.PlatformYouTube, "yt-video")
+	ytAudio, _ := domain.NewTrack("Bohemian Rhapsody", "Queen - Topic", domain.PlatformYouTube, "yt-audio")
+
+	mockClient := &mockYouTubeClient{
+		isrcResults: map[string]*domain.Track{},
+		trackResults: map[string][]*domain.Track{
+			"Bohemian Rhapsody|Queen": {ytVideo, ytAudio},
+		},
+	}
+
+	sourceTrack, _ := domain.NewTrack("Bohemian Rhapsody", "Queen", domain.PlatformSpotify, "sp1")
+	matcher := NewMatcher(mockClient)
+
+	tests := []struct {
+		preference domain.VideoPreference
+		wantID     string
+	}{
+		{domain.VideoPreferenceAny, "yt-video"},
+		{domain.VideoPreferenceOfficialAudio, "yt-audio"},
+		{domain.VideoPreferenceMusicVideo, "yt-video"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.preference), func(t *testing.T) {
+			opts := domain.MatchOptions{VideoPreference: tt.preference}
+			matches := matcher.MatchTracks(context.Background(), []*domain.Track{sourceTrack}, "session", opts, 1, nil)
+
+			if matches[0].TargetTrack == nil {
+				t.Fatal("expected a target track")
+			}
+			if matches[0].TargetTrack.PlatformID != tt.wantID {
+				t.Errorf("expected %s, got %s", tt.wantID, matches[0].TargetTrack.PlatformID)
+			}
+		})
 	}
 }
 
-func TestMatcher_SearchError(t *testing.T) {
+func TestMatcher_KeepsRankedCandidates(t *testing.T) {
+	ytExact, _ := domain.NewTrack("Queen - Bohemian Rhapsody", "Queen", domain.PlatformYouTube, "yt-exact")
+	ytPartial, _ := domain.NewTrack("Bohemian Rhapsody Piano", "SomeChannel", domain.PlatformYouTube, "yt-partial")
+	ytCover, _ := domain.NewTrack("Bohemian Rhapsody Cover", "CoverChannel", domain.PlatformYouTube, "yt-cover")
+
+	mockClient := &mockYouTubeClient{
+		isrcResults: map[string]*domain.Track{},
+		trackResults: map[string][]*domain.Track{
+			"Bohemian Rhapsody|Queen": {ytPartial, ytCover, ytExact},
+		},
+	}
+
+	sourceTrack, _ := domain.NewTrack("Bohemian Rhapsody", "Queen", domain.PlatformSpotify, "sp1")
+
+	matcher := NewMatcher(mockClient)
+	matches := matcher.MatchTracks(context.Background(), []*domain.Track{sourceTrack}, "session", domain.MatchOptions{}, 1, nil)
+
+	if matches[0].TargetTrack.PlatformID != "yt-exact" {
+		t.Errorf("expected best candidate yt-exact, got %s", matches[0].TargetTrack.PlatformID)
+	}
+
+	var ids []string
+	for _, candidate := range matches[0].Candidates {
+		ids = append(ids, candidate.PlatformID)
+	}
+	if len(ids) != 2 || ids[0] != "yt-exact" || ids[1] != "yt-partial" {
+		t.Errorf("expected candidates [yt-exact yt-partial], got %v", ids)
+	}
+}
+
+func TestMatcher_ExplainsSelection(t *testing.T) {
+	ytExact, _ := domain.NewTrack("Queen - Bohemian Rhapsody (Official Video)", "Queen", domain.PlatformYouTube, "yt-exact")
+	ytPartial, _ := domain.NewTrack("Bohemian Rhapsody Piano", "SomeChannel", domain.PlatformYouTube, "yt-partial")
+	ytCover, _ := domain.NewTrack("Bohemian Rhapsody Cover", "CoverChannel", domain.PlatformYouTube, "yt-cover")
+
+	mockClient := &mockYouTubeClient{
+		isrcResults: map[string]*domain.Track{},
+		trackResults: map[string][]*domain.Track{
+			"Bohemian Rhapsody|Queen": {ytPartial, ytCover, ytExact},
+		},
+	}
+
+	sourceTrack, _ := domain.NewTrack("Bohemian Rhapsody", "Queen", domain.PlatformSpotify, "sp1")
+
+	matcher := NewMatcher(mockClient)
+	match := matcher.MatchTracks(context.Background(), []*domain.Track{sourceTrack}, "session", domain.MatchOptions{}, 1, nil)[0]
+
+	if match.ScoreBreakdown[featureConfidence] != 30 || match.ScoreBreakdown[featurePreferTerms] != 1 {
+		t.Errorf("unexpected score breakdown %v", match.ScoreBreakdown)
+	}
+	if len(match.Rejected) != 2 {
+		t.Fatalf("expected 2 rejected candidates, got %+v", match.Rejected)
+	}
+	if match.Rejected[0].PlatformID != "yt-partial" || match.Rejected[0].Score != 20 {
+		t.Errorf("expected yt-partial rejected with score 20, got %+v", match.Rejected[0])
+	}
+	if match.Rejected[0].Reason != "confidence MEDIUM below selected HIGH" {
+		t.Errorf("expected yt-partial rejected for its lower confidence, got %q", match.Rejected[0].Reason)
+	}
+	if match.Rejected[1].PlatformID != "yt-cover" || !strings.Contains(match.Rejected[1].Reason, `"cover"`) {
+		t.Errorf("expected yt-cover rejected for excluded term, got %+v", match.Rejected[1])
+	}
+}
+
+func TestMatcher_ExplainsRejectionWithinConfidence(t *testing.T) {
+	ytOfficial, _ := domain.NewTrack("Queen - Bohemian Rhapsody (Official Video)", "Queen", domain.PlatformYouTube, "yt-official")
+	ytPlain, _ := domain.NewTrack("Queen - Bohemian Rhapsody", "Queen", domain.PlatformYouTube, "yt-plain")
+
+	mockClient := &mockYouTubeClient{
+		isrcResults: map[string]*domain.Track{},
+		trackResults: map[string][]*domain.Track{
+			"Bohemian Rhapsody|Queen": {ytPlain, ytOfficial},
+		},
+	}
+
+	sourceTrack, _ := domain.NewTrack("Bohemian Rhapsody", "Queen", domain.PlatformSpotify, "sp1")
+
+	matcher := NewMatcher(mockClient)
+	match := matcher.MatchTracks(context.Background(), []*domain.Track{sourceTrack}, "session", domain.MatchOptions{}, 1, nil)[0]
+
+	if match.TargetTrack == nil || match.TargetTrack.PlatformID != "yt-official" {
+		t.Fatalf("expected yt-official to be selected, got %+v", match.TargetTrack)
+	}
+	if len(match.Rejected) != 1 || match.Rejected[0].Reason != "scored 30, below selected 31" {
+		t.Errorf("expected yt-plain rejected for its lower score, got %+v", match.Rejected)
+	}
+}
+
+func TestMatcher_ExplicitPreference(t *testing.T) {
+	ytClean, _ := domain.NewTrack("Eminem - Lose Yourself (Clean Version)", "Eminem", domain.PlatformYouTube, "yt-clean")
+	ytExplicit, _ := domain.NewTrack("Eminem - Lose Yourself (Explicit)", "Eminem", domain.PlatformYouTube, "yt-explicit")
+
+	mockClient := &mockYouTubeClient{
+		isrcResults: map[string]*domain.Track{},
+		trackResults: map[string][]*domain.Track{
+			"Lose Yourself|Eminem": {ytClean, ytExplicit},
+		},
+	}
+
+	explicitSource, _ := domain.NewTrack("Lose Yourself", "Eminem", domain.PlatformSpotify, "sp1")
+	explicitSource.WithExplicit(true)
+
+	matcher := NewMatcher(mockClient)
+	match := matcher.MatchTracks(context.Background(), []*domain.Track{explicitSource}, "session", domain.MatchOptions{}, 1, nil)[0]
+	if match.TargetTrack.PlatformID != "yt-explicit" {
+		t.Errorf("explicit source should match yt-explicit, got %s", match.TargetTrack.PlatformID)
+	}
+
+	cleanSource, _ := domain.NewTrack("Lose Yourself", "Eminem", domain.PlatformSpotify, "sp2")
+	match = matcher.MatchTracks(context.Background(), []*domain.Track{cleanSource}, "session", domain.MatchOptions{Explicit: domain.ExplicitPreferenceClean}, 1, nil)[0]
+	if match.TargetTrack.PlatformID != "yt-clean" {
+		t.Errorf("CLEAN preference should match yt-clean, got %s", match.TargetTrack.PlatformID)
+	}
+	if match.ScoreBreakdown[featureExplicit] != explicitWeight {
+		t.Errorf("expected explicit feature %v, got %v", explicitWeight, match.ScoreBreakdown)
+	}
+}
+
+func TestMatcher_VersionPreference(t *testing.T) {
+	ytRemaster, _ := domain.NewTrack("Queen - Bohemian Rhapsody (Remastered 2011)", "Queen", domain.PlatformYouTube, "yt-remaster")
+	ytOriginal, _ := domain.NewTrack("Queen - Bohemian Rhapsody", "Queen", domain.PlatformYouTube, "yt-original")
+
+	mockClient := &mockYouTubeClient{
+		isrcResults: map[string]*domain.Track{},
+		trackResults: map[string][]*domain.Track{
+			"Bohemian Rhapsody|Queen": {ytRemaster, ytOriginal},
+		},
+	}
+
+	sourceTrack, _ := domain.NewTrack("Bohemian Rhapsody", "Queen", domain.PlatformSpotify, "sp1")
+	matcher := NewMatcher(mockClient)
+
+	match := matcher.MatchTracks(context.Background(), []*domain.Track{sourceTrack}, "session", domain.MatchOptions{Version: domain.VersionPreferenceOriginal}, 1, nil)[0]
+	if match.TargetTrack.PlatformID != "yt-original" {
+		t.Errorf("ORIGINAL preference should match yt-original, got %s", match.TargetTrack.PlatformID)
+	}
+
+	match = matcher.MatchTracks(context.Background(), []*domain.Track{sourceTrack}, "session", domain.MatchOptions{Version: domain.VersionPreferenceRemastered}, 1, nil)[0]
+	if match.TargetTrack.PlatformID != "yt-remaster" {
+		t.Errorf("REMASTERED preference should match yt-remaster, got %s", match.TargetTrack.PlatformID)
+	}
+}
+
+func TestMatcher_SkipsUnplayableCandidates(t *testing.T) {
+	ytISRC, _ := domain.NewTrack("Queen - Bohemian Rhapsody (Official Video)", "Queen", domain.PlatformYouTube, "yt-isrc")
+	ytISRC.WithAvailability(&domain.Availability{Embeddable: true, BlockedRegions: []string{"BR"}})
+	ytBlocked, _ := domain.NewTrack("Queen - Bohemian Rhapsody (Official Audio)", "Queen", domain.PlatformYouTube, "yt-blocked")
+	ytBlocked.WithAvailability(&domain.Availability{Embeddable: true, AllowedRegions: []string{"US"}})
+	ytNoEmbed, _ := domain.NewTrack("Queen - Bohemian Rhapsody", "Queen", domain.PlatformYouTube, "yt-no-embed")
+	ytNoEmbed.WithAvailability(&domain.Availability{Embeddable: false})
+	ytPlayable, _ := domain.NewTrack("Bohemian Rhapsody Lyrics", "Queen", domain.PlatformYouTube, "yt-playable")
+
+	mockClient := &mockYouTubeClient{
+		isrcResults: map[string]*domain.Track{"GBUM71029604": ytISRC},
+		trackResults: map[string][]*domain.Track{
+			"Bohemian Rhapsody|Queen": {ytBlocked, ytNoEmbed, ytPlayable},
+		},
+	}
+
+	sourceTrack, _ := domain.NewTrack("Bohemian Rhapsody", "Queen", domain.PlatformSpotify, "sp1")
+	sourceTrack.WithISRC("GBUM71029604")
+
+	matcher := NewMatcher(mockClient)
+	match := matcher.MatchTracks(context.Background(), []*domain.Track{sourceTrack}, "session", domain.MatchOptions{Market: "BR"}, 1, nil)[0]
+
+	if match.TargetTrack == nil || match.TargetTrack.PlatformID != "yt-playable" {
+		t.Fatalf("expected yt-playable, got %+v", match.TargetTrack)
+	}
+	if len(match.Rejected) != 2 || !strings.Contains(match.Rejected[0].Reason, "BR") || !strings.Contains(match.Rejected[1].Reason, "embedded") {
+		t.Errorf("expected region and embedding rejections, got %+v", match.Rejected)
+	}
+	if market := mockClient.market(); market != "BR" {
+		t.Errorf("expected market BR to be passed to search, got %q", market)
+	}
+}
+
+type memoryMatchCache struct {
+	mu     sync.Mutex
+	isrc   map[string]*domain.Track
+	search map[string][]*domain.Track
+}
+
+func newMemoryMatchCache() *memoryMatchCache {
+	return &memoryMatchCache{
+		isrc:   map[string]*domain.Track{},
+		search: map[string][]*domain.Track{},
+	}
+}
+
+func (c *memoryMatchCache) GetISRC(ctx context.Context, isrc string) (*domain.Track, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	track, found := c.isrc[isrc]
+	return track, found, nil
+}
+
+func (c *memoryMatchCache) SetISRC(ctx context.Context, isrc string, track *domain.Track) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.isrc[isrc] = track
+	return nil
+}
+
+func (c *memoryMatchCache) GetSearch(ctx context.Context, fingerprint string) ([]*domain.Track, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	tracks, found := c.search[fingerprint]
+	return tracks, found, nil
+}
+
+func (c *memoryMatchCache) SetSearch(ctx context.Context, fingerprint string, tracks []*domain.Track) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.search[fingerprint] = tracks
+	return nil
+}
+
+func TestMatcher_CacheAvoidsRepeatedSearches(t *testing.T) {
+	ytTrack, _ := domain.NewTrack("Queen - Bohemian Rhapsody", "Queen", domain.PlatformYouTube, "yt1")
+
+	mockClient := &mockYouTubeClient{
+		isrcResults: map[string]*domain.Track{},
+		trackResults: map[string][]*domain.Track{
+			"Bohemian Rhapsody|Queen": {ytTrack},
+		},
+	}
+	cache := newMemoryMatchCache()
+	matcher := NewMatcher(mockClient, WithMatchCache(cache))
+
+	first, _ := domain.NewTrack("Bohemian Rhapsody", "Queen", domain.PlatformSpotify, "sp1")
+	second, _ := domain.NewTrack("Bohemian Rhapsody", "Queen", domain.PlatformSpotify, "sp1")
+
+	matcher.MatchTracks(context.Background(), []*domain.Track{first}, "session-a", domain.MatchOptions{}, 1, nil)
+	matches := matcher.MatchTracks(context.Background(), []*domain.Track{second}, "session-b", domain.MatchOptions{}, 1, nil)
+
+	if calls := mockClient.searchCalls.Load(); calls != 1 {
+		t.Errorf("expected 1 upstream search, got %d", calls)
+	}
+	if matches[0].TargetTrack == nil || matches[0].TargetTrack.PlatformID != "yt1" {
+		t.Error("expected cached result to be matched")
+	}
+}
+
+func TestMatcher_CachesNotFound(t *testing.T) {
+	mockClient := &mockYouTubeClient{
+		isrcResults:  map[string]*domain.Track{},
+		trackResults: map[string][]*domain.Track{},
+	}
+	cache := newMemoryMatchCache()
+	matcher := NewMatcher(mockClient, WithMatchCache(cache))
+
+	sourceTrack, _ := domain.NewTrack("Unknown Song", "Unknown Artist", domain.PlatformSpotify, "sp1")
+	sourceTrack.WithISRC("XX0000000000")
+
+	matcher.MatchTracks(context.Background(), []*domain.Track{sourceTrack}, "session", domain.MatchOptions{}, 1, nil)
+	matches := matcher.MatchTracks(context.Background(), []*domain.Track{sourceTrack}, "session", domain.MatchOptions{}, 1, nil)
+
+	if calls := mockClient.searchCalls.Load(); calls != 3 {
+		t.Errorf("expected 3 upstream searches (ISRC, text and title fallback, once each), got %d", calls)
+	}
+	if matches[0].Confidence != domain.MatchConfidenceNone {
+		t.Errorf("expected NONE confidence from negative cache, got %v", matches[0].Confidence)
+	}
+}
+
+func TestMatcher_SearchErrorsAreNotCached(t *testing.T) {
 	mockClient := &mockYouTubeClient{
 		searchError: errors.New("network error"),
 	}
+	cache := newMemoryMatchCache()
+	matcher := NewMatcher(mockClient, WithMatchCache(cache))
 
 	sourceTrack, _ := domain.NewTrack("Test Track", "Test Artist", domain.PlatformSpotify, "sp1")
+	matcher.MatchTracks(context.Background(), []*domain.Track{sourceTrack}, "session", domain.MatchOptions{}, 1, nil)
 
-	matcher := NewMatcher(mockClient)
-	matches := matcher.MatchTracks(context.Background(), []*domain.Track{sourceTrack}, "session", 1, nil)
+	if len(cache.search) != 0 {
+		t.Errorf("expected failed searches not to be cached, got %d entries", len(cache.search))
+	}
+}
 
-	if len(matches) != 1 {
-		t.Fatalf("expected 1 match, got %d", len(matches))
+func TestMatcher_MappingTakesPrecedence(t *testing.T) {
+	ytISRC, _ := domain.NewTrack("Bohemian Rhapsody (Official Video)", "Queen", domain.PlatformYouTube, "yt-isrc")
+
+	mockClient := &mockYouTubeClient{
+		isrcResults: map[string]*domain.Track{
+			"GBUM71029604": ytISRC,
+		},
+		trackResults: map[string][]*domain.Track{},
+	}
+
+	sourceTrack, _ := domain.NewTrack("Bohemian Rhapsody", "Queen", domain.PlatformSpotify, "sp1")
+	sourceTrack.WithISRC("GBUM71029604")
+
+	mappedTarget, _ := domain.NewTrack("Bohemian Rhapsody (Remastered 2011)", "Queen - Topic", domain.PlatformYouTube, "yt-mapped")
+	mapping, _ := domain.NewTrackMapping(sourceTrack, mappedTarget)
+	mappings := newMemoryMappingRepository()
+	mappings.RecordVote(context.Background(), mapping, domain.MappingVoteCorrection)
+	mappings.RecordVote(context.Background(), mapping, domain.MappingVoteMatch)
+	mappings.RecordVote(context.Background(), mapping, domain.MappingVoteMatch)
+
+	matcher := NewMatcher(mockClient, WithTrackMappings(mappings))
+	matches := matcher.MatchTracks(context.Background(), []*domain.Track{sourceTrack}, "session", domain.MatchOptions{}, 1, nil)
+
+	if matches[0].MatchMethod != "mapping" {
+		t.Errorf("expected method 'mapping', got %s", matches[0].MatchMethod)
+	}
+	if matches[0].TargetTrack.PlatformID != "yt-mapped" {
+		t.Errorf("expected yt-mapped, got %s", matches[0].TargetTrack.PlatformID)
+	}
+	if calls := mockClient.searchCalls.Load(); calls != 0 {
+		t.Errorf("expected no upstream searches, got %d", calls)
 	}
+}
 
+func TestMatcher_MappingSkipped(t *testing.T) {
+	tests := []struct {
+		name    string
+		title   string
+		votes   []domain.MappingVote
+		blocked []string
+		opts    domain.MatchOptions
+	}{
+		{
+			name:  "single correction",
+			title: "Bohemian Rhapsody",
+			votes: []domain.MappingVote{domain.MappingVoteCorrection},
+		},
+		{
+			name:  "excluded term",
+			title: "Bohemian Rhapsody (Karaoke Version)",
+			votes: []domain.MappingVote{domain.MappingVoteMatch, domain.MappingVoteMatch, domain.MappingVoteMatch},
+		},
+		{
+			name:    "not available in market",
+			title:   "Bohemian Rhapsody",
+			votes:   []domain.MappingVote{domain.MappingVoteMatch, domain.MappingVoteMatch, domain.MappingVoteMatch},
+			blocked: []string{"BR"},
+			opts:    domain.MatchOptions{Market: "BR"},
+		},
+		{
+			name:  "version preference",
+			title: "Bohemian Rhapsody (Remastered 2011)",
+			votes: []domain.MappingVote{domain.MappingVoteMatch, domain.MappingVoteMatch, domain.MappingVoteMatch},
+			opts:  domain.MatchOptions{Version: domain.VersionPreferenceOriginal},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ytISRC, _ := domain.NewTrack("Bohemian Rhapsody (Official Video)", "Queen", domain.PlatformYouTube, "yt-isrc")
+			mockClient := &mockYouTubeClient{
+				isrcResults:  map[string]*domain.Track{"GBUM71029604": ytISRC},
+				trackResults: map[string][]*domain.Track{},
+			}
+
+			sourceTrack, _ := domain.NewTrack("Bohemian Rhapsody", "Queen", domain.PlatformSpotify, "sp1")
+			sourceTrack.WithISRC("GBUM71029604")
+
+			mappedTarget, _ := domain.NewTrack(tt.title, "Queen - Topic", domain.PlatformYouTube, "yt-mapped")
+			if tt.blocked != nil {
+				mappedTarget.Availability = &domain.Availability{Embeddable: true, BlockedRegions: tt.blocked}
+			}
+			mapping, _ := domain.NewTrackMapping(sourceTrack, mappedTarget)
+			mappings := newMemoryMappingRepository()
+			for _, vote := range tt.votes {
+				mappings.RecordVote(context.Background(), mapping, vote)
+			}
+
+			matches := NewMatcher(mockClient, WithTrackMappings(mappings)).MatchTracks(context.Background(), []*domain.Track{sourceTrack}, "session", tt.opts, 1, nil)
+			if matches[0].MatchMethod == "mapping" || matches[0].TargetTrack.PlatformID != "yt-isrc" {
+				t.Errorf("expected the mapping to be skipped for the ISRC match, got %s via %s", matches[0].TargetTrack.PlatformID, matches[0].MatchMethod)
+			}
+		})
+	}
+}
+
+func TestMatcher_FallbackCascade(t *testing.T) {
+	ytCanonical, _ := domain.NewTrack("Queen - Don't Stop Me Now", "Queen", domain.PlatformYouTube, "yt-canonical")
+	ytAlbum, _ := domain.NewTrack("Don't Stop Me Now (Jazz)", "Queen", domain.PlatformYouTube, "yt-album")
+
+	mockClient := &mockYouTubeClient{
+		isrcResults: map[string]*domain.Track{},
+		trackResults: map[string][]*domain.Track{
+			"Don't Stop Me Now|Queen":                   {ytCanonical},
+			"Jazz Don't Stop Me Now - Remastered 2011|": {ytAlbum},
+		},
+	}
+
+	sourceTrack, _ := domain.NewTrack("Don't Stop Me Now - Remastered 2011", "Queen", domain.PlatformSpotify, "sp1")
+	sourceTrack.WithAlbum("Jazz")
+
+	matcher := NewMatcher(mockClient)
+	matches := matcher.MatchTracks(context.Background(), []*domain.Track{sourceTrack}, "session", domain.MatchOptions{}, 1, nil)
+
+	if matches[0].TargetTrack == nil || matches[0].TargetTrack.PlatformID != "yt-canonical" {
+		t.Fatalf("expected canonical title variant to match, got %+v", matches[0])
+	}
+	if matches[0].MatchMethod != "partial_match:canonical_title" {
+		t.Errorf("expected method 'partial_match:canonical_title', got %s", matches[0].MatchMethod)
+	}
+	if calls := mockClient.searchCalls.Load(); calls != 3 {
+		t.Errorf("expected cascade to stop after 3 searches, got %d", calls)
+	}
+}
+
+func TestMatcher_ScoringWeightsChangeRanking(t *testing.T) {
+	ytOfficial, _ := domain.NewTrack("Bohemian Rhapsody (Official Video)", "Queen", domain.PlatformYouTube, "yt-official")
+	ytPlain, _ := domain.NewTrack("Bohemian Rhapsody", "Queen", domain.PlatformYouTube, "yt-plain")
+
+	mockClient := &mockYouTubeClient{
+		isrcResults: map[string]*domain.Track{},
+		trackResults: map[string][]*domain.Track{
+			"Bohemian Rhapsody|Queen": {ytOfficial, ytPlain},
+		},
+	}
+
+	sourceTrack, _ := domain.NewTrack("Bohemian Rhapsody", "Queen", domain.PlatformSpotify, "sp1")
+
+	matches := NewMatcher(mockClient).MatchTracks(context.Background(), []*domain.Track{sourceTrack}, "session", domain.MatchOptions{}, 1, nil)
+	if matches[0].TargetTrack.PlatformID != "yt-official" {
+		t.Fatalf("expected default scoring to prefer the official video, got %s", matches[0].TargetTrack.PlatformID)
+	}
+
+	weighted := NewMatcher(mockClient, WithScoringWeights(domain.ScoringWeights{featurePreferTerms: -1}))
+	matches = weighted.MatchTracks(context.Background(), []*domain.Track{sourceTrack}, "session", domain.MatchOptions{}, 1, nil)
+	if matches[0].TargetTrack.PlatformID != "yt-plain" {
+		t.Errorf("expected learned weights to flip the ranking, got %s", matches[0].TargetTrack.PlatformID)
+	}
+	if len(matches[0].Rejected) != 1 || matches[0].Rejected[0].Breakdown[featurePreferTerms] != preferTermsWeight {
+		t.Errorf("expected rejected candidate to keep its raw breakdown, got %+v", matches[0].Rejected)
+	}
+}
+
+func TestMatcher_ScoringWeightsKeepConfidenceOrder(t *testing.T) {
+	ytOther, _ := domain.NewTrack("Radio Ga Ga", "Queen - Topic", domain.PlatformYouTube, "yt-other")
+	ytOther.WithOfficialChannel(true)
+	ytExact, _ := domain.NewTrack("Bohemian Rhapsody", "Queen", domain.PlatformYouTube, "yt-exact")
+
+	mockClient := &mockYouTubeClient{
+		isrcResults: map[string]*domain.Track{},
+		trackResults: map[string][]*domain.Track{
+			"Bohemian Rhapsody|Queen": {ytOther, ytExact},
+		},
+	}
+
+	sourceTrack, _ := domain.NewTrack("Bohemian Rhapsody", "Queen", domain.PlatformSpotify, "sp1")
+
+	weighted := NewMatcher(mockClient, WithScoringWeights(domain.ScoringWeights{featureConfidence: 0.1, featureOfficialChannel: 50}))
+	matches := weighted.MatchTracks(context.Background(), []*domain.Track{sourceTrack}, "session", domain.MatchOptions{}, 1, nil)
+	if matches[0].TargetTrack.PlatformID != "yt-exact" || matches[0].Confidence != domain.MatchConfidenceHigh {
+		t.Errorf("expected the HIGH candidate to outrank a heavily weighted weaker one, got %s at %s", matches[0].TargetTrack.PlatformID, matches[0].Confidence)
+	}
+}
+
+func TestMatcher_PrefersOfficialChannel(t *testing.T) {
+	ytFan, _ := domain.NewTrack("Queen - Bohemian Rhapsody", "QueenFan99", domain.PlatformYouTube, "yt-fan")
+	ytTopic, _ := domain.NewTrack("Bohemian Rhapsody", "Queen - Topic", domain.PlatformYouTube, "yt-topic")
+	ytTopic.WithKind(domain.ResultKindSong).WithOfficialChannel(true)
+
+	mockClient := &mockYouTubeClient{
+		isrcResults: map[string]*domain.Track{},
+		trackResults: map[string][]*domain.Track{
+			"Bohemian Rhapsody|Queen": {ytFan, ytTopic},
+		},
+	}
+
+	sourceTrack, _ := domain.NewTrack("Bohemian Rhapsody", "Queen", domain.PlatformSpotify, "sp1")
+
+	matches := NewMatcher(mockClient).MatchTracks(context.Background(), []*domain.Track{sourceTrack}, "session", domain.MatchOptions{}, 1, nil)
+
+	if matches[0].TargetTrack.PlatformID != "yt-topic" {
+		t.Errorf("expected the official channel to win, got %s", matches[0].TargetTrack.PlatformID)
+	}
+	if matches[0].ScoreBreakdown[featureOfficialChannel] != officialChannelWeight {
+		t.Errorf("expected official channel feature in breakdown, got %v", matches[0].ScoreBreakdown)
+	}
+}
+
+func TestMatcher_OfficialChannelMustBelongToArtist(t *testing.T) {
+	sourceTrack, _ := domain.NewTrack("Under Pressure", "Queen", domain.PlatformSpotify, "sp1")
+	sourceTrack.WithArtists([]string{"Queen", "David Bowie"})
+
+	tests := []struct {
+		channel string
+		want    bool
+	}{
+		{"Queen - Topic", true},
+		{"QueenVEVO", true},
+		{"David Bowie - Topic", true},
+		{"DavidBowieVEVO", true},
+		{"Queen Official", true},
+		{"Vanilla Ice - Topic", false},
+		{"Queensryche - Topic", false},
+	}
+
+	for _, tt := range tests {
+		target, _ := domain.NewTrack("Under Pressure", tt.channel, domain.PlatformYouTube, "yt1")
+		target.WithOfficialChannel(true)
+		if got := isArtistOfficialChannel(sourceTrack, target); got != tt.want {
+			t.Errorf("isArtistOfficialChannel(%q) = %v, want %v", tt.channel, got, tt.want)
+		}
+	}
+}
+
+func TestMatcher_VideoPreferenceUsesResultKind(t *testing.T) {
+	ytVideo, _ := domain.NewTrack("Bohemian Rhapsody", "QueenVEVO", domain.PlatformYouTube, "yt-video")
+	ytVideo.WithKind(domain.ResultKindVideo).WithOfficialChannel(true)
+	ytSong, _ := domain.NewTrack("Bohemian Rhapsody", "Queen - Topic", domain.PlatformYouTube, "yt-song")
+	ytSong.WithKind(domain.ResultKindSong).WithOfficialChannel(true)
+
+	mockClient := &mockYouTubeClient{
+		isrcResults: map[string]*domain.Track{},
+		trackResults: map[string][]*domain.Track{
+			"Bohemian Rhapsody|Queen": {ytVideo, ytSong},
+		},
+	}
+
+	sourceTrack, _ := domain.NewTrack("Bohemian Rhapsody", "Queen", domain.PlatformSpotify, "sp1")
+	matcher := NewMatcher(mockClient)
+
+	tests := []struct {
+		preference domain.VideoPreference
+		want       string
+	}{
+		{domain.VideoPreferenceOfficialAudio, "yt-song"},
+		{domain.VideoPreferenceMusicVideo, "yt-video"},
+	}
+
+	for _, tt := range tests {
+		opts := domain.MatchOptions{VideoPreference: tt.preference}
+		matches := matcher.MatchTracks(context.Background(), []*domain.Track{sourceTrack}, "session", opts, 1, nil)
+		if matches[0].TargetTrack.PlatformID != tt.want {
+			t.Errorf("%s: expected %s, got %s", tt.preference, tt.want, matches[0].TargetTrack.PlatformID)
+		}
+	}
+}
+
+type memoryChannelListRepository struct {
+	entries map[string][]*domain.ChannelListEntry
+}
+
+func (r *memoryChannelListRepository) ListByScope(ctx context.Context, scope string) ([]*domain.ChannelListEntry, error) {
+	return r.entries[scope], nil
+}
+
+func (r *memoryChannelListRepository) Put(ctx context.Context, entry *domain.ChannelListEntry) error {
+	r.entries[entry.Scope] = append(r.entries[entry.Scope], entry)
+	return nil
+}
+
+func (r *memoryChannelListRepository) Delete(ctx context.Context, entry *domain.ChannelListEntry) error {
+	return nil
+}
+
+func TestMatcher_PreservesSourceOrder(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	delays := map[string]time.Duration{}
+	mockClient := &mockYouTubeClient{
+		isrcResults:  map[string]*domain.Track{},
+		trackResults: map[string][]*domain.Track{},
+		searchDelay:  func(track string) time.Duration { return delays[track] },
+	}
+
+	var tracks []*domain.Track
+	for i := 0; i < 20; i++ {
+		name := fmt.Sprintf("Song %d", i)
+		id := fmt.Sprintf("sp%d", i)
+		video, _ := domain.NewTrack(name, "Queen", domain.PlatformYouTube, "yt-"+id)
+		mockClient.trackResults[name+"|Queen"] = []*domain.Track{video}
+		delays[name] = time.Duration(rng.Intn(20)) * time.Millisecond
+		track, _ := domain.NewTrack(name, "Queen", domain.PlatformSpotify, id)
+		tracks = append(tracks, track)
+	}
+
+	matches := NewMatcher(mockClient).MatchTracks(context.Background(), tracks, "session-1", domain.MatchOptions{}, 8, nil)
+
+	if len(matches) != len(tracks) {
+		t.Fatalf("expected %d matches, got %d", len(tracks), len(matches))
+	}
+	for i, match := range matches {
+		if match.SourceTrack != tracks[i] {
+			t.Fatalf("expected match %d to be for %s, got %s", i, tracks[i].Name, match.SourceTrack.Name)
+		}
+		if match.TargetTrack == nil || match.TargetTrack.PlatformID != "yt-"+tracks[i].PlatformID {
+			t.Errorf("expected match %d to target yt-%s", i, tracks[i].PlatformID)
+		}
+	}
+}
+
+func TestMatcher_ChannelLists(t *testing.T) {
+	ytSpam, _ := domain.NewTrack("Queen - Bohemian Rhapsody (Official Video)", "Lyrics Reuploads", domain.PlatformYouTube, "yt-spam")
+	ytSpam.WithChannelID("UC-spam")
+	ytBlockedVideo, _ := domain.NewTrack("Queen - Bohemian Rhapsody", "Queen", domain.PlatformYouTube, "yt-blocked")
+	ytPlain, _ := domain.NewTrack("Bohemian Rhapsody", "Queen Archive", domain.PlatformYouTube, "yt-plain")
+	ytTrusted, _ := domain.NewTrack("Bohemian Rhapsody", "Queen Trusted", domain.PlatformYouTube, "yt-trusted")
+	ytTrusted.WithChannelID("UC-trusted")
+	// Copies the trusted channel's title, which must not make it trusted.
+	ytImpostor, _ := domain.NewTrack("Queen - Bohemian Rhapsody", "Queen Trusted", domain.PlatformYouTube, "yt-impostor")
+	ytImpostor.WithChannelID("UC-impostor")
+
+	mockClient := &mockYouTubeClient{
+		isrcResults: map[string]*domain.Track{},
+		trackResults: map[string][]*domain.Track{
+			"Bohemian Rhapsody|Queen": {ytSpam, ytBlockedVideo, ytImpostor, ytPlain, ytTrusted},
+		},
+	}
+
+	blockedChannel, _ := domain.NewChannelListEntry("", domain.ChannelListBlock, domain.ChannelListTargetChannel, "UC-spam", "")
+	trusted, _ := domain.NewChannelListEntry("", domain.ChannelListAllow, domain.ChannelListTargetChannel, "UC-trusted", "")
+	blockedVideo, _ := domain.NewChannelListEntry("user-1", domain.ChannelListBlock, domain.ChannelListTargetVideo, "yt-blocked", "")
+	repo := &memoryChannelListRepository{entries: map[string][]*domain.ChannelListEntry{
+		domain.ChannelListScopeGlobal: {trusted},
+		"user-1":                      {blockedVideo},
+	}}
+
+	sourceTrack, _ := domain.NewTrack("Bohemian Rhapsody", "Queen", domain.PlatformSpotify, "sp1")
+	matcher := NewMatcher(mockClient, WithChannelLists([]*domain.ChannelListEntry{blockedChannel}, repo))
+
+	matches := matcher.MatchTracks(context.Background(), []*domain.Track{sourceTrack}, "user-1", domain.MatchOptions{}, 1, nil)
+
+	if matches[0].TargetTrack.PlatformID != "yt-trusted" {
+		t.Errorf("expected the trusted channel to win, got %s", matches[0].TargetTrack.PlatformID)
+	}
+	for _, c := range matches[0].Candidates {
+		if c.PlatformID == "yt-spam" || c.PlatformID == "yt-blocked" {
+			t.Errorf("expected blocklisted %s not to be a candidate", c.PlatformID)
+		}
+	}
+
+	matches = matcher.MatchTracks(context.Background(), []*domain.Track{sourceTrack}, "user-2", domain.MatchOptions{}, 1, nil)
+	found := false
+	for _, c := range matches[0].Candidates {
+		found = found || c.PlatformID == "yt-blocked"
+	}
+	if !found {
+		t.Error("expected a per-user block not to apply to other users")
+	}
+}
+
+func TestMatcher_ChannelListsBlockISRCResult(t *testing.T) {
+	ytISRC, _ := domain.NewTrack("Bohemian Rhapsody", "Lyrics Reuploads", domain.PlatformYouTube, "yt-isrc")
+	ytISRC.WithChannelID("UC-spam")
+	ytSearch, _ := domain.NewTrack("Bohemian Rhapsody", "Queen", domain.PlatformYouTube, "yt-search")
+
+	mockClient := &mockYouTubeClient{
+		isrcResults: map[string]*domain.Track{"GBUM71029604": ytISRC},
+		trackResults: map[string][]*domain.Track{
+			"Bohemian Rhapsody|Queen": {ytSearch},
+		},
+	}
+
+	blocked, _ := domain.NewChannelListEntry("", domain.ChannelListBlock, domain.ChannelListTargetChannel, "UC-spam", "")
+	sourceTrack, _ := domain.NewTrack("Bohemian Rhapsody", "Queen", domain.PlatformSpotify, "sp1")
+	sourceTrack.WithISRC("GBUM71029604")
+
+	matches := NewMatcher(mockClient, WithChannelLists([]*domain.ChannelListEntry{blocked}, nil)).
+		MatchTracks(context.Background(), []*domain.Track{sourceTrack}, "user-1", domain.MatchOptions{}, 1, nil)
+
+	if matches[0].TargetTrack.PlatformID != "yt-search" {
+		t.Errorf("expected blocked ISRC result to fall through to search, got %s", matches[0].TargetTrack.PlatformID)
+	}
+}
+
+func TestMatcher_ClassicalMovement(t *testing.T) {
+	ytWrongMovement, _ := domain.NewTrack("Beethoven: Symphony No. 5 in C Minor, Op. 67 - IV. Allegro", "Berliner Philharmoniker", domain.PlatformYouTube, "yt-iv")
+	ytFullWork, _ := domain.NewTrack("Beethoven - Symphony No. 5 Op. 67 (Full)", "Classical Channel", domain.PlatformYouTube, "yt-full")
+	ytMovement, _ := domain.NewTrack("Beethoven - Symphony No. 5, Op. 67: I. Allegro con brio", "Deutsche Grammophon", domain.PlatformYouTube, "yt-i")
+
+	title := "Symphony No. 5 in C minor, Op. 67: I. Allegro con brio"
+	mockClient := &mockYouTubeClient{
+		isrcResults: map[string]*domain.Track{},
+		trackResults: map[string][]*domain.Track{
+			title + "|Ludwig van Beethoven": {ytWrongMovement, ytFullWork, ytMovement},
+		},
+	}
+
+	sourceTrack, _ := domain.NewTrack(title, "Ludwig van Beethoven", domain.PlatformSpotify, "sp1")
+
+	matches := NewMatcher(mockClient).MatchTracks(context.Background(), []*domain.Track{sourceTrack}, "session", domain.MatchOptions{}, 1, nil)
+
+	if matches[0].TargetTrack == nil || matches[0].TargetTrack.PlatformID != "yt-i" {
+		t.Fatalf("expected the matching movement, got %+v", matches[0].TargetTrack)
+	}
+	if matches[0].Confidence != domain.MatchConfidenceHigh || matches[0].MatchMethod != classicalMatchMethod {
+		t.Errorf("expected HIGH classical_match, got %s %s", matches[0].Confidence, matches[0].MatchMethod)
+	}
+}
+
+func TestClassicalConfidence_OpusNeedsComposer(t *testing.T) {
+	title := "Symphony No. 5 in C minor, Op. 67"
+	source, _ := domain.NewTrack(title, "Ludwig van Beethoven", domain.PlatformSpotify, "sp1")
+	work, _ := domain.ParseClassicalTitle(title)
+
+	otherComposer, _ := domain.NewTrack("Mendelssohn - Lieder ohne Worte, Op. 67 (Full)", "Classical Piano", domain.PlatformYouTube, "yt-mendelssohn")
+	if got := classicalConfidence(source, work, otherComposer); got != domain.MatchConfidenceNone {
+		t.Errorf("expected another composer's Op. 67 not to match, got %s", got)
+	}
+
+	bach, _ := domain.NewTrack("Cello Suite No. 1 in G major, BWV 1007", "Cello Channel", domain.PlatformYouTube, "yt-bach")
+	bachSource, _ := domain.NewTrack("Suite No. 1, BWV 1007", "Mstislav Rostropovich", domain.PlatformSpotify, "sp2")
+	bachWork, _ := domain.ParseClassicalTitle(bachSource.Name)
+	if got := classicalConfidence(bachSource, bachWork, bach); got != domain.MatchConfidenceMedium {
+		t.Errorf("expected a BWV number to identify the work without the composer, got %s", got)
+	}
+}
+
+func TestMatcher_ClassicalWorkFallbackQuery(t *testing.T) {
+	ytPrelude, _ := domain.NewTrack("Bach - Cello Suite No. 1 in G major, BWV 1007 - Prelude", "Yo-Yo Ma", domain.PlatformYouTube, "yt-prelude")
+
+	mockClient := &mockYouTubeClient{
+		isrcResults: map[string]*domain.Track{},
+		trackResults: map[string][]*domain.Track{
+			"Suite No. 1 bwv 1007 movement 1|Johann Sebastian Bach": {ytPrelude},
+		},
+	}
+
+	sourceTrack, _ := domain.NewTrack("Cello Suite No. 1 in G Major, BWV 1007: I. Prélude", "Johann Sebastian Bach", domain.PlatformSpotify, "sp1")
+
+	matches := NewMatcher(mockClient).MatchTracks(context.Background(), []*domain.Track{sourceTrack}, "session", domain.MatchOptions{}, 1, nil)
+
+	if matches[0].TargetTrack == nil || matches[0].TargetTrack.PlatformID != "yt-prelude" {
+		t.Fatalf("expected the classical work query to find the prelude, got %+v", matches[0])
+	}
+	if matches[0].MatchMethod != classicalMatchMethod+":"+queryVariantClassicalWork {
+		t.Errorf("unexpected match method %s", matches[0].MatchMethod)
+	}
+}
+
+func TestMatcher_CompilationUsesTrackArtists(t *testing.T) {
+	ytOther, _ := domain.NewTrack("Hey Ya! (Party Hits Mix)", "Now That's What I Call Music", domain.PlatformYouTube, "yt-mix")
+	ytOriginal, _ := domain.NewTrack("OutKast - Hey Ya! (Official HD Video)", "OutKast", domain.PlatformYouTube, "yt-original")
+
+	mockClient := &mockYouTubeClient{
+		isrcResults: map[string]*domain.Track{},
+		trackResults: map[string][]*domain.Track{
+			"Hey Ya!|OutKast": {ytOther, ytOriginal},
+		},
+	}
+
+	sourceTrack, _ := domain.NewTrack("Hey Ya!", "Various Artists", domain.PlatformSpotify, "sp1")
+	sourceTrack.WithArtists([]string{"OutKast"})
+
+	matches := NewMatcher(mockClient).MatchTracks(context.Background(), []*domain.Track{sourceTrack}, "session", domain.MatchOptions{}, 1, nil)
+
+	if matches[0].TargetTrack == nil || matches[0].TargetTrack.PlatformID != "yt-original" {
+		t.Fatalf("expected the track artist's video, got %+v", matches[0].TargetTrack)
+	}
+	if matches[0].Confidence != domain.MatchConfidenceHigh {
+		t.Errorf("expected HIGH confidence, got %s", matches[0].Confidence)
+	}
+}
+
+func TestMatcher_StrategiesCanBeDisabled(t *testing.T) {
+	ytISRC, _ := domain.NewTrack("Bohemian Rhapsody (Official Video)", "Queen", domain.PlatformYouTube, "yt-isrc")
+	ytSearch, _ := domain.NewTrack("Bohemian Rhapsody", "Queen", domain.PlatformYouTube, "yt-search")
+
+	mockClient := &mockYouTubeClient{
+		isrcResults: map[string]*domain.Track{
+			"GBUM71029604": ytISRC,
+		},
+		trackResults: map[string][]*domain.Track{
+			"Bohemian Rhapsody|Queen": {ytSearch},
+		},
+	}
+
+	sourceTrack, _ := domain.NewTrack("Bohemian Rhapsody", "Queen", domain.PlatformSpotify, "sp1")
+	sourceTrack.WithISRC("GBUM71029604")
+
+	matcher := NewMatcher(mockClient, WithStrategies([]string{"search"}))
+	matches := matcher.MatchTracks(context.Background(), []*domain.Track{sourceTrack}, "session", domain.MatchOptions{}, 1, nil)
+
+	if matches[0].TargetTrack == nil || matches[0].TargetTrack.PlatformID != "yt-search" {
+		t.Fatalf("expected search strategy to match, got %+v", matches[0])
+	}
+	if calls := mockClient.searchCalls.Load(); calls != 1 {
+		t.Errorf("expected only the search strategy to run, got %d calls", calls)
+	}
+}
+
+func TestMatcher_StrategiesCanBeReordered(t *testing.T) {
+	ytISRC, _ := domain.NewTrack("Bohemian Rhapsody (Official Video)", "Queen", domain.PlatformYouTube, "yt-isrc")
+	ytSearch, _ := domain.NewTrack("Bohemian Rhapsody", "Queen", domain.PlatformYouTube, "yt-search")
+
+	mockClient := &mockYouTubeClient{
+		isrcResults: map[string]*domain.Track{
+			"GBUM71029604": ytISRC,
+		},
+		trackResults: map[string][]*domain.Track{
+			"Bohemian Rhapsody|Queen": {ytSearch},
+		},
+	}
+
+	sourceTrack, _ := domain.NewTrack("Bohemian Rhapsody", "Queen", domain.PlatformSpotify, "sp1")
+	sourceTrack.WithISRC("GBUM71029604")
+
+	matcher := NewMatcher(mockClient, WithStrategies([]string{"search", "unknown", "isrc"}))
+	matches := matcher.MatchTracks(context.Background(), []*domain.Track{sourceTrack}, "session", domain.MatchOptions{}, 1, nil)
+
+	if matches[0].TargetTrack == nil || matches[0].TargetTrack.PlatformID != "yt-search" {
+		t.Fatalf("expected search to run before ISRC, got %+v", matches[0])
+	}
+}
+
+func TestValidateStrategies(t *testing.T) {
+	if err := ValidateStrategies([]string{"mapping", " Cache ", "isrc", "search", "fallback"}); err != nil {
+		t.Errorf("expected known strategies to be valid, got %v", err)
+	}
+	if err := ValidateStrategies([]string{"search", "fuzzy"}); err == nil {
+		t.Error("expected an unknown strategy to be rejected")
+	}
+}
+
+func TestMatchStrategy_CacheSkipsUpstream(t *testing.T) {
+	ytCached, _ := domain.NewTrack("Bohemian Rhapsody", "Queen", domain.PlatformYouTube, "yt-cached")
+	ytISRC, _ := domain.NewTrack("Bohemian Rhapsody (Official Video)", "Queen", domain.PlatformYouTube, "yt-isrc")
+	mockClient := &mockYouTubeClient{isrcResults: map[string]*domain.Track{"GBUM71029604": ytISRC}}
+
+	cache := newMemoryMatchCache()
+	withISRC, _ := domain.NewTrack("Bohemian Rhapsody", "Queen", domain.PlatformSpotify, "sp1")
+	withISRC.WithISRC("GBUM71029604")
+	withoutISRC, _ := domain.NewTrack("Radio Ga Ga", "Queen", domain.PlatformSpotify, "sp2")
+	ytRadio, _ := domain.NewTrack("Queen - Radio Ga Ga", "Queen", domain.PlatformYouTube, "yt-radio")
+	cache.SetSearch(context.Background(), withoutISRC.Fingerprint(), []*domain.Track{ytRadio})
+	cache.SetSearch(context.Background(), withISRC.Fingerprint(), []*domain.Track{ytCached})
+
+	m := NewMatcher(mockClient, WithMatchCache(cache)).(*matcher)
+	strategies := buildStrategies(m, []string{StrategyCache})
+	rules := newMatchRules(domain.MatchOptions{})
+
+	if match, _ := strategies[0].Match(context.Background(), withoutISRC, "session", rules); match == nil || match.TargetTrack.PlatformID != "yt-radio" {
+		t.Errorf("expected cached search results to match, got %+v", match)
+	}
+	if match, _ := strategies[0].Match(context.Background(), withISRC, "session", rules); match != nil {
+		t.Errorf("expected an uncached ISRC to defer to the isrc strategy, got %+v", match)
+	}
+	if calls := mockClient.searchCalls.Load(); calls != 0 {
+		t.Errorf("expected the cache strategy not to call YouTube, got %d calls", calls)
+	}
+
+	matches := m.MatchTracks(context.Background(), []*domain.Track{withISRC}, "session", domain.MatchOptions{}, 1, nil)
+	if matches[0].TargetTrack.PlatformID != "yt-isrc" {
+		t.Errorf("expected the ISRC result to win over cached search results, got %s", matches[0].TargetTrack.PlatformID)
+	}
+}
+
+func TestMatchStrategy_ISRCInIsolation(t *testing.T) {
+	ytISRC, _ := domain.NewTrack("Bohemian Rhapsody (Official Video)", "Queen", domain.PlatformYouTube, "yt-isrc")
+
+	mockClient := &mockYouTubeClient{
+		isrcResults: map[string]*domain.Track{
+			"GBUM71029604": ytISRC,
+		},
+	}
+
+	sourceTrack, _ := domain.NewTrack("Bohemian Rhapsody", "Queen", domain.PlatformSpotify, "sp1")
+	sourceTrack.WithISRC("GBUM71029604")
+
+	strategies := buildStrategies(NewMatcher(mockClient).(*matcher), []string{"isrc"})
+	if len(strategies) != 1 || strategies[0].Name() != StrategyISRC {
+		t.Fatalf("expected a single isrc strategy, got %+v", strategies)
+	}
+
+	rules := newMatchRules(domain.MatchOptions{})
+	match, err := strategies[0].Match(context.Background(), sourceTrack, "session", rules)
+	if err != nil || match == nil || match.TargetTrack.PlatformID != "yt-isrc" {
+		t.Fatalf("expected isrc strategy to match, got %+v, %v", match, err)
+	}
+}
+
+func TestMatcher_FallbackIgnoresWeakCandidates(t *testing.T) {
+	ytUnrelated, _ := domain.NewTrack("Some Music Video", "RandomChannel", domain.PlatformYouTube, "yt1")
+
+	mockClient := &mockYouTubeClient{
+		isrcResults: map[string]*domain.Track{},
+		trackResults: map[string][]*domain.Track{
+			"Bohemian Rhapsody|": {ytUnrelated},
+		},
+	}
+
+	sourceTrack, _ := domain.NewTrack("Bohemian Rhapsody", "Queen", domain.PlatformSpotify, "sp1")
+
+	matcher := NewMatcher(mockClient)
+	matches := matcher.MatchTracks(context.Background(), []*domain.Track{sourceTrack}, "session", domain.MatchOptions{}, 1, nil)
+
+	if matches[0].Confidence != domain.MatchConfidenceNone {
+		t.Errorf("expected NONE confidence, got %v", matches[0].Confidence)
+	}
+}
+
+type isrcEnricher map[string]string
+
+func (e isrcEnricher) Enrich(ctx context.Context, track *domain.Track) error {
+	if isrc, ok := e[track.Name]; ok && track.ISRC == "" {
+		track.WithISRC(isrc)
+	}
+	return nil
+}
+
+func TestMatcher_EnricherEnablesISRCSearch(t *testing.T) {
+	ytTrack, _ := domain.NewTrack("Bohemian Rhapsody (Official Video)", "Queen", domain.PlatformYouTube, "yt1")
+
+	mockClient := &mockYouTubeClient{
+		isrcResults:  map[string]*domain.Track{"GBUM71029604": ytTrack},
+		trackResults: map[string][]*domain.Track{},
+	}
+
+	sourceTrack, _ := domain.NewTrack("Bohemian Rhapsody", "Queen", domain.PlatformYouTube, "src1")
+
+	enricher := ChainEnrichers(isrcEnricher{}, isrcEnricher{"Bohemian Rhapsody": "GBUM71029604"})
+	matcher := NewMatcher(mockClient, WithEnricher(enricher))
+	matches := matcher.MatchTracks(context.Background(), []*domain.Track{sourceTrack}, "session", domain.MatchOptions{}, 1, nil)
+
+	if matches[0].MatchMethod != "isrc" {
+		t.Errorf("expected method 'isrc', got %s", matches[0].MatchMethod)
+	}
+	if sourceTrack.ISRC != "GBUM71029604" {
+		t.Errorf("expected source track to be enriched with ISRC, got %q", sourceTrack.ISRC)
+	}
+}
+
+func TestMatcher_EmptyTracks(t *testing.T) {
+	mockClient := &mockYouTubeClient{}
+	matcher := NewMatcher(mockClient)
+
+	matches := matcher.MatchTracks(context.Background(), nil, "session", domain.MatchOptions{}, 1, nil)
+	if matches != nil {
+		t.Errorf("expected nil for nil tracks, got %v", matches)
+	}
+
+	matches = matcher.MatchTracks(context.Background(), []*domain.Track{}, "session", domain.MatchOptions{}, 1, nil)
+	if matches != nil {
+		t.Errorf("expected nil for empty slice, got %v", matches)
+	}
+}
+
+func TestMatcher_ProgressCallback(t *testing.T) {
+	ytTrack, _ := domain.NewTrack("Track 1", "Artist", domain.PlatformYouTube, "yt1")
+
+	mockClient := &mockYouTubeClient{
+		isrcResults: map[string]*domain.Track{},
+		trackResults: map[string][]*domain.Track{
+			"Track 1|Artist": {ytTrack},
+			"Track 2|Artist": {ytTrack},
+		},
+	}
+
+	track1, _ := domain.NewTrack("Track 1", "Artist", domain.PlatformSpotify, "sp1")
+	track2, _ := domain.NewTrack("Track 2", "Artist", domain.PlatformSpotify, "sp2")
+
+	matcher := NewMatcher(mockClient)
+
+	var progressCalls int
+	matches := matcher.MatchTracks(context.Background(), []*domain.Track{track1, track2}, "session", domain.MatchOptions{}, 2, func(match *domain.TrackMatch, processed, matched, needsReview, failed int) {
+		progressCalls++
+	})
+
+	if len(matches) != 2 {
+		t.Errorf("expected 2 matches, got %d", len(matches))
+	}
+
+	if progressCalls != 2 {
+		t.Errorf("expected 2 progress calls, got %d", progressCalls)
+	}
+}
+
+func TestMatcher_SearchError(t *testing.T) {
+	mockClient := &mockYouTubeClient{
+		searchError: errors.New("network error"),
+	}
+
+	sourceTrack, _ := domain.NewTrack("Test Track", "Test Artist", domain.PlatformSpotify, "sp1")
+
+	matcher := NewMatcher(mockClient)
+	matches := matcher.MatchTracks(context.Background(), []*domain.Track{sourceTrack}, "session", domain.MatchOptions{}, 1, nil)
+
+	if len(matches) != 1 {
+		t.Fatalf("expected 1 match, got %d", len(matches))
+	}
+
+	if !matches[0].SearchFailed {
+		t.Error("expected a failed search to be marked as such, not as a confirmed miss")
+	}
 	if matches[0].Confidence != domain.MatchConfidenceNone {
 		t.Errorf("expected NONE confidence on error, got %v", matches[0].Confidence)
 	}
@@ -300,7 +1424,7 @@ func TestIsExcluded(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.title, func(t *testing.T) {
-			if isExcluded(tt.title) != tt.excluded {
+			if newMatchRules(domain.MatchOptions{}).isExcluded(tt.title) != tt.excluded {
 				t.Errorf("isExcluded(%q) = %v, want %v", tt.title, !tt.excluded, tt.excluded)
 			}
 		})
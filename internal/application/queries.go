@@ -0,0 +1,68 @@
+package application
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/domain"
+)
+
+const (
+	queryVariantTitleOnly      = "title_only"
+	queryVariantPrimaryArtist  = "primary_artist"
+	queryVariantCanonicalTitle = "canonical_title"
+	queryVariantAlbumTitle     = "album_title"
+	queryVariantClassicalWork  = "classical_work"
+)
+
+var bracketedPattern = regexp.MustCompile(`\s*[\(\[][^\)\]]*[\)\]]`)
+var artistSeparatorPattern = regexp.MustCompile(`(?i)\s*(,|&|\bfeat\.?|\bft\.?|\bfeaturing\b|\bwith\b|\bx\b)\s+`)
+
+type searchQuery struct {
+	variant string
+	track   string
+	artist  string
+}
+
+func fallbackQueries(track *domain.Track) []searchQuery {
+	var queries []searchQuery
+	if work, ok := domain.ParseClassicalTitle(track.Name); ok {
+		queries = append(queries, searchQuery{variant: queryVariantClassicalWork, track: work.Query(), artist: classicalQueryArtist(track, work)})
+	}
+
+	queries = append(queries, searchQuery{variant: queryVariantTitleOnly, track: track.Name})
+
+	if primary := primaryArtist(track.Artist); primary != "" && primary != track.Artist && !domain.IsCompilationArtist(track.Artist) {
+		queries = append(queries, searchQuery{variant: queryVariantPrimaryArtist, track: track.Name, artist: primary})
+	}
+
+	if canonical := canonicalTitle(track.Name); canonical != "" && canonical != track.Name {
+		queries = append(queries, searchQuery{variant: queryVariantCanonicalTitle, track: canonical, artist: searchArtist(track)})
+	}
+
+	if track.Album != "" && !strings.EqualFold(track.Album, track.Name) {
+		queries = append(queries, searchQuery{variant: queryVariantAlbumTitle, track: track.Album + " " + track.Name})
+	}
+
+	return queries
+}
+
+func primaryArtist(artist string) string {
+	parts := artistSeparatorPattern.Split(artist, 2)
+	return strings.TrimSpace(parts[0])
+}
+
+func canonicalTitle(title string) string {
+	canonical := bracketedPattern.ReplaceAllString(title, "")
+	if i := strings.Index(canonical, " - "); i > 0 {
+		canonical = canonical[:i]
+	}
+	return strings.TrimSpace(canonical)
+}
+
+func classicalQueryArtist(track *domain.Track, work domain.ClassicalWork) string {
+	if work.Composer != "" {
+		return work.Composer
+	}
+	return primaryArtist(searchArtist(track))
+}
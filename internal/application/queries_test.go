@@ -0,0 +1,74 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/domain"
+)
+
+func TestPrimaryArtist(t *testing.T) {
+	tests := []struct {
+		artist string
+		want   string
+	}{
+		{"Queen", "Queen"},
+		{"Queen, David Bowie", "Queen"},
+		{"Daft Punk feat. Pharrell Williams", "Daft Punk"},
+		{"Calvin Harris & Dua Lipa", "Calvin Harris"},
+		{"Lil Nas X", "Lil Nas X"},
+		{"Kanye West x Lil Pump", "Kanye West"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.artist, func(t *testing.T) {
+			if got := primaryArtist(tt.artist); got != tt.want {
+				t.Errorf("primaryArtist(%q) = %q, want %q", tt.artist, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCanonicalTitle(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{"Bohemian Rhapsody", "Bohemian Rhapsody"},
+		{"Bohemian Rhapsody (Remastered 2011)", "Bohemian Rhapsody"},
+		{"Get Lucky [Radio Edit] (feat. Pharrell)", "Get Lucky"},
+		{"Don't Stop Me Now - Remastered 2011", "Don't Stop Me Now"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			if got := canonicalTitle(tt.title); got != tt.want {
+				t.Errorf("canonicalTitle(%q) = %q, want %q", tt.title, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFallbackQueries(t *testing.T) {
+	track, _ := domain.NewTrack("Get Lucky (Radio Edit)", "Daft Punk feat. Pharrell Williams", domain.PlatformSpotify, "sp1")
+	track.WithAlbum("Random Access Memories")
+
+	var variants []string
+	for _, q := range fallbackQueries(track) {
+		variants = append(variants, q.variant)
+	}
+
+	want := []string{queryVariantTitleOnly, queryVariantPrimaryArtist, queryVariantCanonicalTitle, queryVariantAlbumTitle}
+	if len(variants) != len(want) {
+		t.Fatalf("fallbackQueries() variants = %v, want %v", variants, want)
+	}
+	for i := range want {
+		if variants[i] != want[i] {
+			t.Errorf("variant[%d] = %s, want %s", i, variants[i], want[i])
+		}
+	}
+
+	simple, _ := domain.NewTrack("Bohemian Rhapsody", "Queen", domain.PlatformSpotify, "sp2")
+	if got := fallbackQueries(simple); len(got) != 1 || got[0].variant != queryVariantTitleOnly {
+		t.Errorf("expected only the title variant for a simple track, got %+v", got)
+	}
+}
@@ -0,0 +1,156 @@
+package application
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/domain"
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/metrics"
+)
+
+const (
+	featureConfidence      = "confidence"
+	featurePreferTerms     = "prefer_terms"
+	featureVideoPreference = "video_preference"
+	featureExplicit        = "explicit"
+	featureVersion         = "version"
+	featureOfficialChannel = "official_channel"
+	featureTrustedChannel  = "trusted_channel"
+)
+
+const (
+	confidenceWeight      = 10
+	preferTermsWeight     = 1
+	videoPreferenceWeight = 2
+	explicitWeight        = 2
+	versionWeight         = 2
+	officialChannelWeight = 2
+	trustedChannelWeight  = 3
+)
+
+var explicitTerms = []string{"explicit", "uncensored", "dirty version"}
+var cleanTerms = []string{"clean version", "(clean)", "[clean]", "radio edit", "censored"}
+var remasterTerms = []string{"remaster"}
+var officialChannelSuffixes = []string{" - Topic", "VEVO", " Official"}
+
+func (r *matchRules) scoreFeatures(source, target *domain.Track, confidence domain.MatchConfidence) domain.ScoreBreakdown {
+	breakdown := domain.ScoreBreakdown{
+		featureConfidence: float64(confidence.Rank() * confidenceWeight),
+	}
+
+	if containsAny(target.Name, r.preferTerms) {
+		breakdown[featurePreferTerms] = preferTermsWeight
+	}
+	if r.matchesVideoPreference(target) {
+		breakdown[featureVideoPreference] = videoPreferenceWeight
+	}
+	if isArtistOfficialChannel(source, target) {
+		breakdown[featureOfficialChannel] = officialChannelWeight
+	}
+	if r.channelLists.IsTrusted(target) {
+		metrics.ChannelListHits.WithLabelValues("trusted").Inc()
+		breakdown[featureTrustedChannel] = trustedChannelWeight
+	}
+	if score := r.explicitScore(source, target); score != 0 {
+		breakdown[featureExplicit] = score
+	}
+	if score := r.versionScore(source, target); score != 0 {
+		breakdown[featureVersion] = score
+	}
+
+	return breakdown
+}
+
+// isArtistOfficialChannel reports whether target comes from the official channel of one of the
+// source's artists, so another artist's "- Topic" or VEVO channel earns no boost.
+func isArtistOfficialChannel(source, target *domain.Track) bool {
+	if !target.OfficialChannel {
+		return false
+	}
+	channel := channelArtist(target.Artist)
+	if channel == "" {
+		return false
+	}
+	for _, artist := range slices.Concat(matchArtists(source), source.Artists) {
+		if compactName(artist) == channel {
+			return true
+		}
+	}
+	return false
+}
+
+// channelArtist strips the suffixes official channels add to the artist name,
+// e.g. "Taylor Swift - Topic" and "TaylorSwiftVEVO" both give "taylorswift".
+func channelArtist(channel string) string {
+	channel = strings.TrimSpace(channel)
+	for _, suffix := range officialChannelSuffixes {
+		if len(channel) > len(suffix) && strings.EqualFold(channel[len(channel)-len(suffix):], suffix) {
+			channel = channel[:len(channel)-len(suffix)]
+			break
+		}
+	}
+	return compactName(channel)
+}
+
+func compactName(name string) string {
+	return strings.ReplaceAll(domain.NormalizeText(name), " ", "")
+}
+
+func (r *matchRules) explicitScore(source, target *domain.Track) float64 {
+	wantExplicit := r.explicitPreference == domain.ExplicitPreferenceExplicit ||
+		(r.explicitPreference == domain.ExplicitPreferenceSource && source.Explicit)
+	wantClean := r.explicitPreference == domain.ExplicitPreferenceClean
+
+	// "uncensored" contains "censored", so explicit tags take precedence.
+	isExplicit := target.Explicit || containsAny(target.Name, explicitTerms)
+	isClean := !isExplicit && containsAny(target.Name, cleanTerms)
+
+	switch {
+	case (wantExplicit && isExplicit) || (wantClean && isClean):
+		return explicitWeight
+	case (wantExplicit && isClean) || (wantClean && isExplicit):
+		return -explicitWeight
+	default:
+		return 0
+	}
+}
+
+func (r *matchRules) versionScore(source, target *domain.Track) float64 {
+	if !containsAny(target.Name, remasterTerms) {
+		return 0
+	}
+
+	switch r.versionPreference {
+	case domain.VersionPreferenceRemastered:
+		return versionWeight
+	case domain.VersionPreferenceOriginal:
+		return -versionWeight
+	default:
+		if containsAny(source.Name, remasterTerms) {
+			return versionWeight
+		}
+		return 0
+	}
+}
+
+func (r *matchRules) matchesVideoPreference(target *domain.Track) bool {
+	if target.Kind != domain.ResultKindUnknown {
+		switch r.videoPreference {
+		case domain.VideoPreferenceOfficialAudio:
+			return target.Kind == domain.ResultKindSong
+		case domain.VideoPreferenceMusicVideo:
+			return target.Kind == domain.ResultKindVideo && (target.OfficialChannel || containsAny(target.Name, musicVideoTerms))
+		default:
+			return false
+		}
+	}
+
+	switch r.videoPreference {
+	case domain.VideoPreferenceOfficialAudio:
+		return containsAny(target.Name, officialAudioTerms) || containsAny(target.Artist, officialAudioTerms)
+	case domain.VideoPreferenceMusicVideo:
+		return containsAny(target.Name, musicVideoTerms)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,108 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/domain"
+)
+
+const (
+	StrategyMapping  = "mapping"
+	StrategyCache    = "cache"
+	StrategyISRC     = "isrc"
+	StrategySearch   = "search"
+	StrategyFallback = "fallback"
+)
+
+var DefaultStrategies = []string{StrategyMapping, StrategyCache, StrategyISRC, StrategySearch, StrategyFallback}
+
+// matchStrategy is one step of the matcher's chain. It returns a nil match to hand the track to the
+// next step, and an error when a lookup failed, so that "nothing found" can be told apart from "could not search".
+type matchStrategy interface {
+	Name() string
+	Match(ctx context.Context, sourceTrack *domain.Track, sessionID string, rules *matchRules) (*domain.TrackMatch, error)
+}
+
+type mappingStrategy struct{ m *matcher }
+
+func (s mappingStrategy) Name() string { return StrategyMapping }
+
+func (s mappingStrategy) Match(ctx context.Context, sourceTrack *domain.Track, sessionID string, rules *matchRules) (*domain.TrackMatch, error) {
+	return s.m.tryMapping(ctx, sourceTrack, rules)
+}
+
+type cacheStrategy struct{ m *matcher }
+
+func (s cacheStrategy) Name() string { return StrategyCache }
+
+func (s cacheStrategy) Match(ctx context.Context, sourceTrack *domain.Track, sessionID string, rules *matchRules) (*domain.TrackMatch, error) {
+	return s.m.tryCache(ctx, sourceTrack, rules)
+}
+
+type isrcStrategy struct{ m *matcher }
+
+func (s isrcStrategy) Name() string { return StrategyISRC }
+
+func (s isrcStrategy) Match(ctx context.Context, sourceTrack *domain.Track, sessionID string, rules *matchRules) (*domain.TrackMatch, error) {
+	return s.m.tryISRCSearch(ctx, sourceTrack, sessionID, rules)
+}
+
+type searchStrategy struct{ m *matcher }
+
+func (s searchStrategy) Name() string { return StrategySearch }
+
+func (s searchStrategy) Match(ctx context.Context, sourceTrack *domain.Track, sessionID string, rules *matchRules) (*domain.TrackMatch, error) {
+	return s.m.tryMusicSearch(ctx, sourceTrack, sessionID, rules)
+}
+
+type fallbackStrategy struct{ m *matcher }
+
+func (s fallbackStrategy) Name() string { return StrategyFallback }
+
+func (s fallbackStrategy) Match(ctx context.Context, sourceTrack *domain.Track, sessionID string, rules *matchRules) (*domain.TrackMatch, error) {
+	return s.m.tryFallbackSearches(ctx, sourceTrack, sessionID, rules)
+}
+
+var strategyFactories = map[string]func(m *matcher) matchStrategy{
+	StrategyMapping:  func(m *matcher) matchStrategy { return mappingStrategy{m} },
+	StrategyCache:    func(m *matcher) matchStrategy { return cacheStrategy{m} },
+	StrategyISRC:     func(m *matcher) matchStrategy { return isrcStrategy{m} },
+	StrategySearch:   func(m *matcher) matchStrategy { return searchStrategy{m} },
+	StrategyFallback: func(m *matcher) matchStrategy { return fallbackStrategy{m} },
+}
+
+// ValidateStrategies rejects unknown strategy names, so a misconfigured chain fails at startup
+// instead of silently matching with fewer strategies.
+func ValidateStrategies(names []string) error {
+	for _, name := range names {
+		if _, ok := strategyFactories[normalizeStrategy(name)]; !ok {
+			return fmt.Errorf("unknown match strategy %q", name)
+		}
+	}
+	return nil
+}
+
+func buildStrategies(m *matcher, names []string) []matchStrategy {
+	if len(names) == 0 {
+		names = DefaultStrategies
+	}
+
+	var strategies []matchStrategy
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		name = normalizeStrategy(name)
+		factory, ok := strategyFactories[name]
+		if !ok || seen[name] {
+			continue
+		}
+		seen[name] = true
+		strategies = append(strategies, factory(m))
+	}
+	return strategies
+}
+
+func normalizeStrategy(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
@@ -0,0 +1,148 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/domain"
+)
+
+type ConversionLogReader interface {
+	ListForTraining(ctx context.Context) ([]*domain.ConversionLog, error)
+}
+
+type TrainingExample struct {
+	Features domain.ScoreBreakdown
+	Label    float64
+}
+
+type TrainingConfig struct {
+	Epochs       int
+	LearningRate float64
+	L2           float64
+}
+
+// BuildTrainingExamples labels every scored candidate of a match log. The user's final pick from a
+// review or swap is the positive example; without a correction, a successful match counts as accepted.
+func BuildTrainingExamples(logs []*domain.ConversionLog) []TrainingExample {
+	corrections := make(map[string]*domain.ConversionLog)
+	rejections := make(map[string]bool)
+
+	for _, l := range logs {
+		key := l.ConversionID + "|" + l.SourceTrackID
+		switch {
+		case l.Step == domain.StepReviewTrack && l.Status == domain.LogStatusSkipped:
+			rejections[key] = true
+		case (l.Step == domain.StepReviewTrack || l.Step == domain.StepSwapTrack) && l.Status == domain.LogStatusSuccess:
+			if latest, ok := corrections[key]; !ok || l.CreatedAt.After(latest.CreatedAt) {
+				corrections[key] = l
+			}
+		}
+	}
+
+	var examples []TrainingExample
+	for _, l := range logs {
+		if l.Step != domain.StepMatchTrack || len(l.ScoreBreakdown) == 0 {
+			continue
+		}
+
+		key := l.ConversionID + "|" + l.SourceTrackID
+		var chosen string
+		if correction, ok := corrections[key]; ok {
+			chosen = correction.TargetTrackID
+		} else if !rejections[key] {
+			if l.Status != domain.LogStatusSuccess {
+				continue
+			}
+			chosen = l.TargetTrackID
+		}
+
+		examples = append(examples, TrainingExample{Features: l.ScoreBreakdown, Label: label(l.TargetTrackID == chosen)})
+		for _, rejected := range l.Rejected {
+			if len(rejected.Breakdown) == 0 {
+				continue
+			}
+			examples = append(examples, TrainingExample{Features: rejected.Breakdown, Label: label(rejected.PlatformID == chosen)})
+		}
+	}
+
+	return examples
+}
+
+// TrainScoringModel fits a logistic regression over the score features with batch gradient descent.
+// Features are scaled to [-1, 1] while training and the weights are mapped back to raw feature units.
+func TrainScoringModel(examples []TrainingExample, cfg TrainingConfig) (*domain.ScoringModel, error) {
+	if len(examples) == 0 {
+		return nil, errors.New("no training examples")
+	}
+
+	scales := make(map[string]float64)
+	for _, ex := range examples {
+		for feature, value := range ex.Features {
+			scales[feature] = math.Max(scales[feature], math.Abs(value))
+		}
+	}
+
+	features := make([]string, 0, len(scales))
+	for feature, scale := range scales {
+		if scale > 0 {
+			features = append(features, feature)
+		}
+	}
+	sort.Strings(features)
+
+	weights := make([]float64, len(features))
+	gradients := make([]float64, len(features))
+	bias := 0.0
+	n := float64(len(examples))
+
+	for epoch := 0; epoch < cfg.Epochs; epoch++ {
+		for i := range gradients {
+			gradients[i] = 0
+		}
+		biasGradient := 0.0
+
+		for _, ex := range examples {
+			z := bias
+			for i, feature := range features {
+				z += weights[i] * ex.Features[feature] / scales[feature]
+			}
+			diff := sigmoid(z) - ex.Label
+			for i, feature := range features {
+				gradients[i] += diff * ex.Features[feature] / scales[feature]
+			}
+			biasGradient += diff
+		}
+
+		for i := range weights {
+			weights[i] -= cfg.LearningRate * (gradients[i]/n + cfg.L2*weights[i])
+		}
+		bias -= cfg.LearningRate * biasGradient / n
+	}
+
+	model := &domain.ScoringModel{
+		Features:  features,
+		Weights:   make(domain.ScoringWeights, len(features)),
+		Bias:      bias,
+		Examples:  len(examples),
+		TrainedAt: time.Now().UTC(),
+	}
+	for i, feature := range features {
+		model.Weights[feature] = weights[i] / scales[feature]
+	}
+	return model, nil
+}
+
+func sigmoid(z float64) float64 {
+	return 1 / (1 + math.Exp(-z))
+}
+
+func label(positive bool) float64 {
+	if positive {
+		return 1
+	}
+	return 0
+}
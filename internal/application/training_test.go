@@ -0,0 +1,85 @@
+package application
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/domain"
+)
+
+func TestBuildTrainingExamples_UsesCorrections(t *testing.T) {
+	source := mustTrack("Bohemian Rhapsody", "Queen")
+	selected, _ := domain.NewTrack("Bohemian Rhapsody (Live)", "Queen", domain.PlatformYouTube, "yt-selected")
+	alternate, _ := domain.NewTrack("Bohemian Rhapsody", "Queen - Topic", domain.PlatformYouTube, "yt-alternate")
+
+	matchLog := domain.NewMatchTrackLog("conv-1", source, selected, domain.LogStatusSuccess)
+	matchLog.ScoreBreakdown = domain.ScoreBreakdown{featureConfidence: 30, featurePreferTerms: 1}
+	rejected := domain.NewRejectedCandidate(alternate, 32, "confidence MEDIUM below selected HIGH")
+	rejected.Breakdown = domain.ScoreBreakdown{featureConfidence: 30, featureVideoPreference: 2}
+	matchLog.Rejected = []domain.RejectedCandidate{
+		rejected,
+		domain.NewRejectedCandidate(alternate, 0, "title contains excluded term \"cover\""),
+	}
+
+	swapLog := domain.NewSwapTrackLog("conv-1", source, selected, alternate, domain.LogStatusSuccess, "")
+	swapLog.CreatedAt = matchLog.CreatedAt.Add(time.Minute)
+
+	examples := BuildTrainingExamples([]*domain.ConversionLog{matchLog, swapLog})
+
+	if len(examples) != 2 {
+		t.Fatalf("expected 2 examples, got %d", len(examples))
+	}
+	if examples[0].Label != 0 {
+		t.Errorf("expected the replaced selection to be a negative example")
+	}
+	if examples[1].Label != 1 || examples[1].Features[featureVideoPreference] != 2 {
+		t.Errorf("expected the swapped-in candidate to be a positive example, got %+v", examples[1])
+	}
+}
+
+func TestBuildTrainingExamples_SkipsUndecidedReviews(t *testing.T) {
+	source := mustTrack("Radio Ga Ga", "Queen")
+	target, _ := domain.NewTrack("Some Music Video", "RandomChannel", domain.PlatformYouTube, "yt1")
+
+	reviewLog := domain.NewMatchTrackReviewLog("conv-1", source, target, "below minimum confidence")
+	reviewLog.ScoreBreakdown = domain.ScoreBreakdown{featureConfidence: 10}
+
+	if examples := BuildTrainingExamples([]*domain.ConversionLog{reviewLog}); len(examples) != 0 {
+		t.Errorf("expected no examples for a pending review, got %d", len(examples))
+	}
+}
+
+func TestTrainScoringModel_LearnsPreferredFeature(t *testing.T) {
+	var examples []TrainingExample
+	for i := 0; i < 20; i++ {
+		examples = append(examples,
+			TrainingExample{Features: domain.ScoreBreakdown{featureConfidence: 30, featureVideoPreference: 2}, Label: 1},
+			TrainingExample{Features: domain.ScoreBreakdown{featureConfidence: 30, featurePreferTerms: 1}, Label: 0},
+		)
+	}
+
+	model, err := TrainScoringModel(examples, TrainingConfig{Epochs: 500, LearningRate: 0.5, L2: 0.001})
+	if err != nil {
+		t.Fatalf("TrainScoringModel() error: %v", err)
+	}
+
+	if model.Weights[featureVideoPreference] <= 0 {
+		t.Errorf("expected a positive weight for video preference, got %v", model.Weights)
+	}
+	if model.Weights[featurePreferTerms] >= 0 {
+		t.Errorf("expected a negative weight for prefer terms, got %v", model.Weights)
+	}
+	if model.Examples != 40 {
+		t.Errorf("Examples = %d, want 40", model.Examples)
+	}
+	if want := []string{featureConfidence, featurePreferTerms, featureVideoPreference}; !slices.Equal(model.Features, want) {
+		t.Errorf("Features = %v, want %v", model.Features, want)
+	}
+}
+
+func TestTrainScoringModel_RequiresExamples(t *testing.T) {
+	if _, err := TrainScoringModel(nil, TrainingConfig{Epochs: 10, LearningRate: 0.1}); err == nil {
+		t.Error("expected error without examples")
+	}
+}
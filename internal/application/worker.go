@@ -62,12 +62,23 @@ func (w *worker) processNextJob(ctx context.Context) {
 	}
 
 	job := queued.Job
-	log.Printf("received job %s: %s -> %s", job.JobID, job.SourcePlatform, job.TargetPlatform)
 
 	jobCtx, cancel := context.WithTimeout(ctx, w.config.JobTimeout)
 	defer cancel()
 
-	if err := w.converter.Convert(jobCtx, job); err != nil {
+	switch job.JobType() {
+	case domain.JobTypeSwapTrack:
+		log.Printf("received swap request for job %s: %d swaps", job.JobID, len(job.Swaps))
+		err = w.converter.SwapTracks(jobCtx, job)
+	case domain.JobTypeReviewDecision:
+		log.Printf("received review decision for job %s: %d decisions", job.JobID, len(job.ReviewDecisions))
+		err = w.converter.ApplyReview(jobCtx, job)
+	default:
+		log.Printf("received job %s: %s -> %s", job.JobID, job.SourcePlatform, job.TargetPlatform)
+		err = w.converter.Convert(jobCtx, job)
+	}
+
+	if err != nil {
 		log.Printf("job %s failed: %v", job.JobID, err)
 		return
 	}
@@ -3,14 +3,20 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
 type Config struct {
-	Redis    RedisConfig
-	AWS      AWSConfig
-	Services ServicesConfig
-	Worker   WorkerConfig
+	Redis        RedisConfig
+	AWS          AWSConfig
+	Services     ServicesConfig
+	Worker       WorkerConfig
+	MatchCache   MatchCacheConfig
+	Enrichment   EnrichmentConfig
+	Matcher      MatcherConfig
+	Experiment   ExperimentConfig
+	ChannelLists ChannelListConfig
 }
 
 type RedisConfig struct {
@@ -21,11 +27,14 @@ type RedisConfig struct {
 }
 
 type AWSConfig struct {
-	Endpoint                string
-	Region                  string
-	SQSQueueURL             string
+	Endpoint                 string
+	Region                   string
+	SQSQueueURL              string
 	DynamoDBConversionsTable string
-	DynamoDBLogsTable       string
+	DynamoDBLogsTable        string
+	DynamoDBMatchesTable     string
+	DynamoDBMappingsTable    string
+	DynamoDBChannelListTable string
 }
 
 type ServicesConfig struct {
@@ -39,8 +48,36 @@ type ServiceConfig struct {
 }
 
 type WorkerConfig struct {
-	Concurrency int
-	JobTimeout  time.Duration
+	Concurrency        int
+	JobTimeout         time.Duration
+	MinMatchConfidence string
+}
+
+type MatchCacheConfig struct {
+	Enabled     bool
+	TTL         time.Duration
+	NegativeTTL time.Duration
+}
+
+type MatcherConfig struct {
+	Strategies  []string
+	WeightsPath string
+}
+
+type ChannelListConfig struct {
+	TrustedChannels []string
+	BlockedChannels []string
+	BlockedVideos   []string
+}
+
+type ExperimentConfig struct {
+	Name     string
+	BucketBy string
+	Variants []string
+}
+
+type EnrichmentConfig struct {
+	MusicBrainzDumpPath string
 }
 
 func Load() *Config {
@@ -57,6 +94,9 @@ func Load() *Config {
 			SQSQueueURL:              getEnv("SQS_QUEUE_URL", ""),
 			DynamoDBConversionsTable: getEnv("DYNAMODB_CONVERSIONS_TABLE", "playswap-conversions"),
 			DynamoDBLogsTable:        getEnv("DYNAMODB_LOGS_TABLE", "playswap-conversion-logs"),
+			DynamoDBMatchesTable:     getEnv("DYNAMODB_MATCHES_TABLE", "playswap-conversion-matches"),
+			DynamoDBMappingsTable:    getEnv("DYNAMODB_MAPPINGS_TABLE", "playswap-track-mappings"),
+			DynamoDBChannelListTable: getEnv("DYNAMODB_CHANNEL_LISTS_TABLE", "playswap-channel-lists"),
 		},
 		Services: ServicesConfig{
 			Spotify: ServiceConfig{
@@ -69,8 +109,31 @@ func Load() *Config {
 			},
 		},
 		Worker: WorkerConfig{
-			Concurrency: getEnvInt("WORKER_CONCURRENCY", 5),
-			JobTimeout:  getEnvDuration("WORKER_JOB_TIMEOUT", 5*time.Minute),
+			Concurrency:        getEnvInt("WORKER_CONCURRENCY", 5),
+			JobTimeout:         getEnvDuration("WORKER_JOB_TIMEOUT", 5*time.Minute),
+			MinMatchConfidence: getEnv("WORKER_MIN_MATCH_CONFIDENCE", "LOW"),
+		},
+		MatchCache: MatchCacheConfig{
+			Enabled:     getEnvBool("MATCH_CACHE_ENABLED", true),
+			TTL:         getEnvDuration("MATCH_CACHE_TTL", 7*24*time.Hour),
+			NegativeTTL: getEnvDuration("MATCH_CACHE_NEGATIVE_TTL", 6*time.Hour),
+		},
+		Matcher: MatcherConfig{
+			Strategies:  getEnvList("MATCHER_STRATEGIES", nil),
+			WeightsPath: getEnv("MATCHER_WEIGHTS_PATH", ""),
+		},
+		Experiment: ExperimentConfig{
+			Name:     getEnv("EXPERIMENT_NAME", ""),
+			BucketBy: getEnv("EXPERIMENT_BUCKET_BY", "USER"),
+			Variants: getEnvList("EXPERIMENT_VARIANTS", nil),
+		},
+		ChannelLists: ChannelListConfig{
+			TrustedChannels: getEnvList("CHANNEL_ALLOWLIST", nil),
+			BlockedChannels: getEnvList("CHANNEL_BLOCKLIST", nil),
+			BlockedVideos:   getEnvList("VIDEO_BLOCKLIST", nil),
+		},
+		Enrichment: EnrichmentConfig{
+			MusicBrainzDumpPath: getEnv("ENRICHMENT_MUSICBRAINZ_DUMP", ""),
 		},
 	}
 }
@@ -95,6 +158,28 @@ func getEnvInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getEnvBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
+
+func getEnvList(key string, defaultValue []string) []string {
+	if value := os.Getenv(key); value != "" {
+		var list []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+		return list
+	}
+	return defaultValue
+}
+
 func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
 	if value := os.Getenv(key); value != "" {
 		if duration, err := time.ParseDuration(value); err == nil {
@@ -0,0 +1,44 @@
+package domain
+
+import "strings"
+
+type Availability struct {
+	Embeddable     bool     `json:"embeddable"`
+	AllowedRegions []string `json:"allowedRegions,omitempty"`
+	BlockedRegions []string `json:"blockedRegions,omitempty"`
+}
+
+func (a *Availability) AvailableIn(market string) bool {
+	if a == nil || market == "" {
+		return true
+	}
+	if len(a.AllowedRegions) > 0 && !containsRegion(a.AllowedRegions, market) {
+		return false
+	}
+	return !containsRegion(a.BlockedRegions, market)
+}
+
+func (a *Availability) IsEmbeddable() bool {
+	return a == nil || a.Embeddable
+}
+
+func containsRegion(regions []string, market string) bool {
+	for _, region := range regions {
+		if strings.EqualFold(region, market) {
+			return true
+		}
+	}
+	return false
+}
+
+func IsValidMarket(market string) bool {
+	if len(market) != 2 {
+		return false
+	}
+	for _, r := range market {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,50 @@
+package domain
+
+import "testing"
+
+func TestAvailability_AvailableIn(t *testing.T) {
+	tests := []struct {
+		name         string
+		availability *Availability
+		market       string
+		want         bool
+	}{
+		{"unknown availability", nil, "BR", true},
+		{"no market", &Availability{BlockedRegions: []string{"BR"}}, "", true},
+		{"blocked region", &Availability{BlockedRegions: []string{"DE", "BR"}}, "BR", false},
+		{"blocked region lower case", &Availability{BlockedRegions: []string{"br"}}, "BR", false},
+		{"allowed region", &Availability{AllowedRegions: []string{"US", "BR"}}, "BR", true},
+		{"outside allowed regions", &Availability{AllowedRegions: []string{"US"}}, "BR", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.availability.AvailableIn(tt.market); got != tt.want {
+				t.Errorf("AvailableIn(%q) = %v, want %v", tt.market, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAvailability_IsEmbeddable(t *testing.T) {
+	var unknown *Availability
+	if !unknown.IsEmbeddable() {
+		t.Error("unknown availability should be embeddable")
+	}
+	if (&Availability{Embeddable: false}).IsEmbeddable() {
+		t.Error("expected non-embeddable video")
+	}
+}
+
+func TestIsValidMarket(t *testing.T) {
+	for _, market := range []string{"US", "BR"} {
+		if !IsValidMarket(market) {
+			t.Errorf("%q should be valid", market)
+		}
+	}
+	for _, market := range []string{"", "us", "USA", "1A"} {
+		if IsValidMarket(market) {
+			t.Errorf("%q should be invalid", market)
+		}
+	}
+}
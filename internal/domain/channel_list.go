@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const ChannelListScopeGlobal = "GLOBAL"
+
+type ChannelListKind string
+
+const (
+	ChannelListAllow ChannelListKind = "ALLOW"
+	ChannelListBlock ChannelListKind = "BLOCK"
+)
+
+type ChannelListTarget string
+
+const (
+	ChannelListTargetChannel ChannelListTarget = "CHANNEL"
+	ChannelListTargetVideo   ChannelListTarget = "VIDEO"
+)
+
+// ChannelListEntry trusts or blocks a channel, or blocks a single video, either for everyone
+// (scope GLOBAL) or for one user (scope is the user ID). Channels are listed by channel ID,
+// since their display titles can change and are not unique.
+type ChannelListEntry struct {
+	Scope     string            `json:"scope"`
+	Kind      ChannelListKind   `json:"kind"`
+	Target    ChannelListTarget `json:"target"`
+	Value     string            `json:"value"`
+	Note      string            `json:"note,omitempty"`
+	CreatedAt time.Time         `json:"createdAt"`
+}
+
+func NewChannelListEntry(scope string, kind ChannelListKind, target ChannelListTarget, value, note string) (*ChannelListEntry, error) {
+	if scope == "" {
+		scope = ChannelListScopeGlobal
+	}
+	if kind != ChannelListAllow && kind != ChannelListBlock {
+		return nil, fmt.Errorf("invalid list kind %q", kind)
+	}
+	if target != ChannelListTargetChannel && target != ChannelListTargetVideo {
+		return nil, fmt.Errorf("invalid list target %q", target)
+	}
+	if kind == ChannelListAllow && target == ChannelListTargetVideo {
+		return nil, errors.New("only channels can be trusted")
+	}
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, errors.New("list value cannot be empty")
+	}
+
+	return &ChannelListEntry{
+		Scope:     scope,
+		Kind:      kind,
+		Target:    target,
+		Value:     value,
+		Note:      note,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// Key identifies an entry within its scope.
+func (e *ChannelListEntry) Key() string {
+	return string(e.Kind) + "#" + string(e.Target) + "#" + e.Value
+}
+
+type ChannelLists struct {
+	trustedChannels map[string]bool
+	blockedChannels map[string]bool
+	blockedVideos   map[string]bool
+}
+
+func NewChannelLists(entries []*ChannelListEntry) *ChannelLists {
+	lists := &ChannelLists{
+		trustedChannels: make(map[string]bool),
+		blockedChannels: make(map[string]bool),
+		blockedVideos:   make(map[string]bool),
+	}
+	for _, e := range entries {
+		switch {
+		case e.Target == ChannelListTargetVideo:
+			lists.blockedVideos[e.Value] = true
+		case e.Kind == ChannelListAllow:
+			lists.trustedChannels[e.Value] = true
+		default:
+			lists.blockedChannels[e.Value] = true
+		}
+	}
+	return lists
+}
+
+// IsTrusted reports whether the track comes from a trusted channel that is not also blocked.
+func (l *ChannelLists) IsTrusted(track *Track) bool {
+	if l == nil || track.ChannelID == "" {
+		return false
+	}
+	return l.trustedChannels[track.ChannelID] && !l.blockedChannels[track.ChannelID]
+}
+
+// BlockReason explains why a track must never be picked, or returns "" when it is allowed.
+func (l *ChannelLists) BlockReason(track *Track) string {
+	if l == nil {
+		return ""
+	}
+	if l.blockedVideos[track.PlatformID] {
+		return "video is blocklisted"
+	}
+	if track.ChannelID != "" && l.blockedChannels[track.ChannelID] {
+		return fmt.Sprintf("channel %q (%s) is blocklisted", track.Artist, track.ChannelID)
+	}
+	return ""
+}
@@ -0,0 +1,59 @@
+package domain
+
+import "testing"
+
+func TestNewChannelListEntry_Validation(t *testing.T) {
+	entry, err := NewChannelListEntry("", ChannelListBlock, ChannelListTargetChannel, " Reupload Spam ", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if entry.Scope != ChannelListScopeGlobal || entry.Value != "Reupload Spam" {
+		t.Errorf("unexpected entry %+v", entry)
+	}
+
+	if _, err := NewChannelListEntry("", ChannelListAllow, ChannelListTargetVideo, "abc", ""); err == nil {
+		t.Error("expected error for trusted video")
+	}
+	if _, err := NewChannelListEntry("", "MAYBE", ChannelListTargetChannel, "abc", ""); err == nil {
+		t.Error("expected error for invalid kind")
+	}
+	if _, err := NewChannelListEntry("", ChannelListBlock, ChannelListTargetChannel, "  ", ""); err == nil {
+		t.Error("expected error for empty value")
+	}
+}
+
+func TestChannelLists(t *testing.T) {
+	trusted, _ := NewChannelListEntry("", ChannelListAllow, ChannelListTargetChannel, "UC-official", "")
+	blockedChannel, _ := NewChannelListEntry("", ChannelListBlock, ChannelListTargetChannel, "UC-spam", "")
+	blockedVideo, _ := NewChannelListEntry("user-1", ChannelListBlock, ChannelListTargetVideo, "yt-bad", "")
+	lists := NewChannelLists([]*ChannelListEntry{trusted, blockedChannel, blockedVideo})
+
+	official, _ := NewTrack("Bohemian Rhapsody", "Queen Official", PlatformYouTube, "yt1")
+	official.WithChannelID("UC-official")
+	renamed, _ := NewTrack("Bohemian Rhapsody", "Totally Not Spam", PlatformYouTube, "yt2")
+	renamed.WithChannelID("UC-spam")
+	impostor, _ := NewTrack("Bohemian Rhapsody", "Queen Official", PlatformYouTube, "yt3")
+	impostor.WithChannelID("UC-impostor")
+	bad, _ := NewTrack("Bohemian Rhapsody", "Someone", PlatformYouTube, "yt-bad")
+
+	if !lists.IsTrusted(official) {
+		t.Error("expected trusted channel ID")
+	}
+	if lists.IsTrusted(impostor) {
+		t.Error("expected a channel with a copied title not to be trusted")
+	}
+	if lists.BlockReason(official) != "" {
+		t.Error("expected trusted channel not to be blocked")
+	}
+	if lists.BlockReason(renamed) == "" {
+		t.Error("expected blocked channel to stay blocked after a rename")
+	}
+	if lists.BlockReason(bad) == "" {
+		t.Error("expected blocked video")
+	}
+
+	var none *ChannelLists
+	if none.IsTrusted(official) || none.BlockReason(bad) != "" {
+		t.Error("expected nil lists to allow everything")
+	}
+}
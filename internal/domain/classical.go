@@ -0,0 +1,157 @@
+package domain
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var classicalFormPattern = regexp.MustCompile(`(?i)\b(symphony|concerto|sonata|sonatina|suite|quartet|quintet|trio|prelude|fugue|etude|étude|nocturne|waltz|mazurka|polonaise|ballade|scherzo|impromptu|rhapsody|overture|requiem|mass|cantata|partita|serenade|divertimento|fantasia|toccata|invention)\b(?:[^:\-–\d]{0,24}?(?:\bno\.?|\bnr\.?|#)\s*(\d+))?`)
+var classicalCatalogPattern = regexp.MustCompile(`(?i)\b(op|opus|bwv|kv|k|hwv|rv|d|woo)\.?\s*(\d+[a-z]?)(?:\s*,?\s*no\.?\s*(\d+))?\b|\b(hob)\.?\s*([ivx]+)\s*:\s*(\d+)`)
+var classicalKeyPattern = regexp.MustCompile(`(?i)\bin\s+([a-g](?:[\s-]?(?:flat|sharp)|b|#|♭|♯)?)[\s-]+(major|minor|dur|moll)\b`)
+var classicalMovementPattern = regexp.MustCompile(`(?:^|[:\-–,]\s*)([IVX]{1,5})\.\s+\S`)
+var classicalMovementWordPattern = regexp.MustCompile(`(?i)\b(?:mvt\.?|movement)\s*(\d+)\b|\b(\d+)(?:st|nd|rd|th)\s+movement\b`)
+
+var compilationArtists = map[string]bool{
+	"various artists":          true,
+	"various":                  true,
+	"va":                       true,
+	"varios artistas":          true,
+	"artistes divers":          true,
+	"verschiedene interpreten": true,
+	"vários artistas":          true,
+}
+
+// ClassicalWork holds the parts of a classical track title that identify a recording.
+type ClassicalWork struct {
+	Composer string
+	Form     string
+	Number   int
+	Key      string
+	Catalog  string
+	Movement int
+}
+
+// ParseClassicalTitle extracts composer, work, catalog number and movement from titles such as
+// "Beethoven: Symphony No. 5 in C minor, Op. 67: I. Allegro con brio". It reports false when the
+// title does not look like a classical work.
+func ParseClassicalTitle(title string) (ClassicalWork, bool) {
+	var work ClassicalWork
+
+	if m := classicalCatalogPattern.FindStringSubmatch(title); m != nil {
+		work.Catalog = normalizeCatalog(m)
+	}
+	if m := classicalFormPattern.FindStringSubmatch(title); m != nil {
+		work.Form = strings.ToLower(m[1])
+		work.Number, _ = strconv.Atoi(m[2])
+	}
+	if m := classicalKeyPattern.FindStringSubmatch(title); m != nil {
+		work.Key = NormalizeText(m[1] + " " + m[2])
+	}
+	work.Movement = parseMovement(title)
+
+	if work.Catalog == "" && (work.Form == "" || (work.Number == 0 && work.Key == "" && work.Movement == 0)) {
+		return ClassicalWork{}, false
+	}
+
+	if i := strings.Index(title, ":"); i > 0 {
+		prefix := strings.TrimSpace(title[:i])
+		if !strings.ContainsAny(prefix, "0123456789") && !classicalFormPattern.MatchString(prefix) {
+			work.Composer = prefix
+		}
+	}
+
+	return work, true
+}
+
+// SameWork reports whether two parsed titles refer to the same piece, ignoring movements.
+func (w ClassicalWork) SameWork(other ClassicalWork) bool {
+	if w.Catalog != "" && other.Catalog != "" {
+		return w.Catalog == other.Catalog
+	}
+	return w.Form != "" && w.Form == other.Form && w.Number > 0 && w.Number == other.Number
+}
+
+// CatalogIdentifiesComposer reports whether the catalog number belongs to a single composer's
+// catalogue. Opus numbers and plain work numbers are reused by every composer.
+func (w ClassicalWork) CatalogIdentifiesComposer() bool {
+	prefix, _, _ := strings.Cut(w.Catalog, " ")
+	switch prefix {
+	case "bwv", "k", "hwv", "rv", "d", "hob":
+		return true
+	default:
+		return false
+	}
+}
+
+// Query builds a compact search query without the tempo marking.
+func (w ClassicalWork) Query() string {
+	var parts []string
+	if w.Form != "" {
+		form := strings.ToUpper(w.Form[:1]) + w.Form[1:]
+		if w.Number > 0 {
+			form += " No. " + strconv.Itoa(w.Number)
+		}
+		parts = append(parts, form)
+	}
+	if w.Catalog != "" {
+		parts = append(parts, w.Catalog)
+	}
+	if w.Movement > 0 {
+		parts = append(parts, "movement "+strconv.Itoa(w.Movement))
+	}
+	return strings.Join(parts, " ")
+}
+
+func IsCompilationArtist(artist string) bool {
+	return compilationArtists[NormalizeText(artist)]
+}
+
+func normalizeCatalog(m []string) string {
+	if m[4] != "" {
+		return "hob " + strings.ToLower(m[5]) + " " + m[6]
+	}
+
+	prefix := strings.ToLower(m[1])
+	switch prefix {
+	case "opus":
+		prefix = "op"
+	case "kv":
+		prefix = "k"
+	}
+
+	catalog := prefix + " " + strings.ToLower(m[2])
+	if m[3] != "" {
+		catalog += " no " + m[3]
+	}
+	return catalog
+}
+
+func parseMovement(title string) int {
+	if m := classicalMovementPattern.FindStringSubmatch(title); m != nil {
+		return romanToInt(m[1])
+	}
+	if m := classicalMovementWordPattern.FindStringSubmatch(title); m != nil {
+		digits := m[1]
+		if digits == "" {
+			digits = m[2]
+		}
+		n, _ := strconv.Atoi(digits)
+		return n
+	}
+	return 0
+}
+
+func romanToInt(numeral string) int {
+	values := map[byte]int{'I': 1, 'V': 5, 'X': 10}
+	total := 0
+	for i := 0; i < len(numeral); i++ {
+		value := values[numeral[i]]
+		if i+1 < len(numeral) && values[numeral[i+1]] > value {
+			total -= value
+		} else {
+			total += value
+		}
+	}
+	return total
+}
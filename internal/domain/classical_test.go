@@ -0,0 +1,100 @@
+package domain
+
+import "testing"
+
+func TestParseClassicalTitle(t *testing.T) {
+	tests := []struct {
+		title string
+		want  ClassicalWork
+		ok    bool
+	}{
+		{
+			title: "Symphony No. 5 in C minor, Op. 67: I. Allegro con brio",
+			want:  ClassicalWork{Form: "symphony", Number: 5, Key: "c minor", Catalog: "op 67", Movement: 1},
+			ok:    true,
+		},
+		{
+			title: "Beethoven: Symphony No. 5 in C Minor, Op. 67 - IV. Allegro",
+			want:  ClassicalWork{Composer: "Beethoven", Form: "symphony", Number: 5, Key: "c minor", Catalog: "op 67", Movement: 4},
+			ok:    true,
+		},
+		{
+			title: "Cello Suite No. 1 in G Major, BWV 1007: I. Prélude",
+			want:  ClassicalWork{Form: "suite", Number: 1, Key: "g major", Catalog: "bwv 1007", Movement: 1},
+			ok:    true,
+		},
+		{
+			title: "Piano Sonata No. 14 in C-Sharp Minor, Op. 27 No. 2 \"Moonlight\": III. Presto agitato",
+			want:  ClassicalWork{Form: "sonata", Number: 14, Key: "c sharp minor", Catalog: "op 27 no 2", Movement: 3},
+			ok:    true,
+		},
+		{
+			title: "Eine kleine Nachtmusik, K. 525: II. Romanze",
+			want:  ClassicalWork{Catalog: "k 525", Movement: 2},
+			ok:    true,
+		},
+		{
+			title: "Piano Sonata in C major, Hob. XVI:50 (2nd movement)",
+			want:  ClassicalWork{Form: "sonata", Key: "c major", Catalog: "hob xvi 50", Movement: 2},
+			ok:    true,
+		},
+		{title: "Bohemian Rhapsody", ok: false},
+		{title: "Symphony of Destruction", ok: false},
+		{title: "Don't Stop Me Now - Remastered 2011", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			got, ok := ParseClassicalTitle(tt.title)
+			if ok != tt.ok {
+				t.Fatalf("ParseClassicalTitle(%q) ok = %v, want %v", tt.title, ok, tt.ok)
+			}
+			if got != tt.want {
+				t.Errorf("ParseClassicalTitle(%q) = %+v, want %+v", tt.title, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClassicalWork_SameWork(t *testing.T) {
+	source, _ := ParseClassicalTitle("Symphony No. 5 in C minor, Op. 67: I. Allegro con brio")
+	sameCatalog, _ := ParseClassicalTitle("Beethoven - Symphony No.5 Op.67 (Full)")
+	otherWork, _ := ParseClassicalTitle("Symphony No. 7 in A major, Op. 92: II. Allegretto")
+
+	if !source.SameWork(sameCatalog) {
+		t.Error("expected matching catalog numbers to be the same work")
+	}
+	if source.SameWork(otherWork) {
+		t.Error("expected different catalog numbers to be different works")
+	}
+}
+
+func TestClassicalWork_CatalogIdentifiesComposer(t *testing.T) {
+	tests := []struct {
+		title string
+		want  bool
+	}{
+		{"Cello Suite No. 1 in G Major, BWV 1007: I. Prélude", true},
+		{"Piano Sonata No. 11 in A Major, K. 331: III. Rondo alla turca", true},
+		{"Symphony No. 5 in C minor, Op. 67: I. Allegro con brio", false},
+		{"Symphony No. 9 in D minor", false},
+	}
+
+	for _, tt := range tests {
+		work, _ := ParseClassicalTitle(tt.title)
+		if got := work.CatalogIdentifiesComposer(); got != tt.want {
+			t.Errorf("CatalogIdentifiesComposer(%q) = %v, want %v", tt.title, got, tt.want)
+		}
+	}
+}
+
+func TestIsCompilationArtist(t *testing.T) {
+	for _, artist := range []string{"Various Artists", "VARIOUS ARTISTS", "Varios Artistas", "VA"} {
+		if !IsCompilationArtist(artist) {
+			t.Errorf("expected %q to be a compilation artist", artist)
+		}
+	}
+	if IsCompilationArtist("Queen") {
+		t.Error("expected Queen not to be a compilation artist")
+	}
+}
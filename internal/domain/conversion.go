@@ -10,17 +10,18 @@ import (
 type ConversionStatus string
 
 const (
-	ConversionStatusPending   ConversionStatus = "PENDING"
-	ConversionStatusFetching  ConversionStatus = "FETCHING"
-	ConversionStatusMatching  ConversionStatus = "MATCHING"
-	ConversionStatusCreating  ConversionStatus = "CREATING"
-	ConversionStatusCompleted ConversionStatus = "COMPLETED"
-	ConversionStatusFailed    ConversionStatus = "FAILED"
+	ConversionStatusPending        ConversionStatus = "PENDING"
+	ConversionStatusFetching       ConversionStatus = "FETCHING"
+	ConversionStatusMatching       ConversionStatus = "MATCHING"
+	ConversionStatusAwaitingReview ConversionStatus = "AWAITING_REVIEW"
+	ConversionStatusCreating       ConversionStatus = "CREATING"
+	ConversionStatusCompleted      ConversionStatus = "COMPLETED"
+	ConversionStatusFailed         ConversionStatus = "FAILED"
 )
 
 func (s ConversionStatus) IsValid() bool {
 	switch s {
-	case ConversionStatusPending, ConversionStatusFetching, ConversionStatusMatching,
+	case ConversionStatusPending, ConversionStatusFetching, ConversionStatusMatching, ConversionStatusAwaitingReview,
 		ConversionStatusCreating, ConversionStatusCompleted, ConversionStatusFailed:
 		return true
 	default:
@@ -46,22 +47,63 @@ type Conversion struct {
 	TotalTracks        int              `json:"totalTracks"`
 	ProcessedTracks    int              `json:"processedTracks"`
 	MatchedTracks      int              `json:"matchedTracks"`
+	ReviewTracks       int              `json:"reviewTracks"`
 	FailedTracks       int              `json:"failedTracks"`
+	FailedInserts      int              `json:"failedInserts"`
+	InsertedVideoIDs   []string         `json:"insertedVideoIds,omitempty"`
 	ErrorMessage       string           `json:"errorMessage,omitempty"`
+	Experiment         string           `json:"experiment,omitempty"`
+	Variant            string           `json:"variant,omitempty"`
 	CreatedAt          time.Time        `json:"createdAt"`
 	UpdatedAt          time.Time        `json:"updatedAt"`
 	CompletedAt        *time.Time       `json:"completedAt,omitempty"`
+	LeaseUntil         *time.Time       `json:"leaseUntil,omitempty"`
+	Version            int              `json:"version"`
+}
+
+type JobType string
+
+const (
+	JobTypeConvert        JobType = "CONVERT"
+	JobTypeReviewDecision JobType = "REVIEW_DECISION"
+	JobTypeSwapTrack      JobType = "SWAP_TRACK"
+)
+
+type ReviewDecision struct {
+	SourceTrackID string `json:"sourceTrackId"`
+	TargetTrackID string `json:"targetTrackId,omitempty"`
+}
+
+func (d ReviewDecision) IsRejection() bool {
+	return d.TargetTrackID == ""
+}
+
+type TrackSwap struct {
+	SourceTrackID string `json:"sourceTrackId"`
+	TargetTrackID string `json:"targetTrackId"`
 }
 
 type ConversionJob struct {
-	JobID              string    `json:"jobId"`
-	UserID             string    `json:"userId"`
-	SourcePlatform     Platform  `json:"sourcePlatform"`
-	TargetPlatform     Platform  `json:"targetPlatform"`
-	SourcePlaylistID   string    `json:"sourcePlaylistId"`
-	SelectedTrackIDs   []string  `json:"selectedTrackIds,omitempty"`
-	TargetPlaylistName string    `json:"targetPlaylistName"`
-	CreatedAt          time.Time `json:"createdAt"`
+	Type               JobType          `json:"type,omitempty"`
+	JobID              string           `json:"jobId"`
+	UserID             string           `json:"userId"`
+	SourcePlatform     Platform         `json:"sourcePlatform"`
+	TargetPlatform     Platform         `json:"targetPlatform"`
+	SourcePlaylistID   string           `json:"sourcePlaylistId"`
+	SelectedTrackIDs   []string         `json:"selectedTrackIds,omitempty"`
+	Market             string           `json:"market,omitempty"`
+	TargetPlaylistName string           `json:"targetPlaylistName"`
+	MatchOptions       MatchOptions     `json:"matchOptions"`
+	ReviewDecisions    []ReviewDecision `json:"reviewDecisions,omitempty"`
+	Swaps              []TrackSwap      `json:"swaps,omitempty"`
+	CreatedAt          time.Time        `json:"createdAt"`
+}
+
+func (j *ConversionJob) JobType() JobType {
+	if j.Type == "" {
+		return JobTypeConvert
+	}
+	return j.Type
 }
 
 func NewConversion(job *ConversionJob) (*Conversion, error) {
@@ -83,6 +125,12 @@ func NewConversion(job *ConversionJob) (*Conversion, error) {
 	if job.SourcePlaylistID == "" {
 		return nil, errors.New("source playlist ID cannot be empty")
 	}
+	if job.Market != "" && !IsValidMarket(job.Market) {
+		return nil, errors.New("market must be an ISO 3166-1 alpha-2 country code")
+	}
+	if err := job.MatchOptions.Validate(); err != nil {
+		return nil, err
+	}
 
 	now := time.Now()
 	return &Conversion{
@@ -98,6 +146,26 @@ func NewConversion(job *ConversionJob) (*Conversion, error) {
 	}, nil
 }
 
+func NewReviewDecisionJob(conversionID, userID string, decisions []ReviewDecision) *ConversionJob {
+	return &ConversionJob{
+		Type:            JobTypeReviewDecision,
+		JobID:           conversionID,
+		UserID:          userID,
+		ReviewDecisions: decisions,
+		CreatedAt:       time.Now(),
+	}
+}
+
+func NewSwapTrackJob(conversionID, userID string, swaps []TrackSwap) *ConversionJob {
+	return &ConversionJob{
+		Type:      JobTypeSwapTrack,
+		JobID:     conversionID,
+		UserID:    userID,
+		Swaps:     swaps,
+		CreatedAt: time.Now(),
+	}
+}
+
 func NewConversionJob(userID string, sourcePlatform, targetPlatform Platform, sourcePlaylistID, targetPlaylistName string) *ConversionJob {
 	return &ConversionJob{
 		JobID:              uuid.New().String(),
@@ -110,8 +178,34 @@ func NewConversionJob(userID string, sourcePlatform, targetPlatform Platform, so
 	}
 }
 
+func (c *Conversion) AssignVariant(experiment, variant string) {
+	c.Experiment = experiment
+	c.Variant = variant
+	c.UpdatedAt = time.Now()
+}
+
+// Claim marks the conversion as taken by a worker until the lease runs out.
+// Other workers leave a claimed conversion alone.
+func (c *Conversion) Claim(lease time.Duration) {
+	now := time.Now()
+	leaseUntil := now.Add(lease)
+	c.LeaseUntil = &leaseUntil
+	c.UpdatedAt = now
+}
+
+// IsClaimed reports whether a worker still holds the conversion at the given time.
+func (c *Conversion) IsClaimed(now time.Time) bool {
+	return c.LeaseUntil != nil && now.Before(*c.LeaseUntil)
+}
+
+// releaseClaim lets any worker take the conversion again once the current one is done with it.
+func (c *Conversion) releaseClaim() {
+	c.LeaseUntil = nil
+}
+
 func (c *Conversion) StartFetching() {
 	c.Status = ConversionStatusFetching
+	c.clearFailure()
 	c.UpdatedAt = time.Now()
 }
 
@@ -122,15 +216,62 @@ func (c *Conversion) StartMatching(totalTracks int, sourcePlaylistName string) {
 	c.UpdatedAt = time.Now()
 }
 
-func (c *Conversion) UpdateProgress(processed, matched, failed int) {
+func (c *Conversion) UpdateProgress(processed, matched, needsReview, failed int) {
 	c.ProcessedTracks = processed
 	c.MatchedTracks = matched
+	c.ReviewTracks = needsReview
 	c.FailedTracks = failed
 	c.UpdatedAt = time.Now()
 }
 
+func (c *Conversion) AwaitReview() {
+	c.Status = ConversionStatusAwaitingReview
+	c.releaseClaim()
+	c.UpdatedAt = time.Now()
+}
+
 func (c *Conversion) StartCreating() {
 	c.Status = ConversionStatusCreating
+	c.clearFailure()
+	c.UpdatedAt = time.Now()
+}
+
+// AttachTargetPlaylist records the created playlist before any video is added,
+// so a retried job keeps using it instead of creating another one.
+func (c *Conversion) AttachTargetPlaylist(targetPlaylistID, targetPlaylistURL string) {
+	c.TargetPlaylistID = targetPlaylistID
+	c.TargetPlaylistURL = targetPlaylistURL
+	c.UpdatedAt = time.Now()
+}
+
+// RecordInsertedVideos remembers videos already added to the target playlist,
+// so a retried job does not add them again.
+func (c *Conversion) RecordInsertedVideos(videoIDs []string) {
+	c.InsertedVideoIDs = append(c.InsertedVideoIDs, videoIDs...)
+	c.UpdatedAt = time.Now()
+}
+
+// PendingInserts returns the videos that were not added to the target playlist yet.
+// A video listed twice is only skipped as many times as it was inserted.
+func (c *Conversion) PendingInserts(videoIDs []string) []string {
+	inserted := make(map[string]int, len(c.InsertedVideoIDs))
+	for _, videoID := range c.InsertedVideoIDs {
+		inserted[videoID]++
+	}
+
+	pending := make([]string, 0, len(videoIDs))
+	for _, videoID := range videoIDs {
+		if inserted[videoID] > 0 {
+			inserted[videoID]--
+			continue
+		}
+		pending = append(pending, videoID)
+	}
+	return pending
+}
+
+func (c *Conversion) RecordFailedInserts(failed int) {
+	c.FailedInserts = failed
 	c.UpdatedAt = time.Now()
 }
 
@@ -141,6 +282,7 @@ func (c *Conversion) Complete(targetPlaylistID, targetPlaylistURL string) {
 	c.TargetPlaylistURL = targetPlaylistURL
 	c.UpdatedAt = now
 	c.CompletedAt = &now
+	c.releaseClaim()
 }
 
 func (c *Conversion) Fail(errorMessage string) {
@@ -149,6 +291,13 @@ func (c *Conversion) Fail(errorMessage string) {
 	c.ErrorMessage = errorMessage
 	c.UpdatedAt = now
 	c.CompletedAt = &now
+	c.releaseClaim()
+}
+
+// clearFailure resets a previous failure when a retried job starts a stage again.
+func (c *Conversion) clearFailure() {
+	c.ErrorMessage = ""
+	c.CompletedAt = nil
 }
 
 func (c *Conversion) Progress() int {
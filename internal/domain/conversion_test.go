@@ -76,6 +76,30 @@ func TestNewConversion_Validation(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "invalid market",
+			job: &ConversionJob{
+				JobID:            "job",
+				UserID:           "user",
+				SourcePlatform:   PlatformSpotify,
+				TargetPlatform:   PlatformYouTube,
+				SourcePlaylistID: "playlist",
+				Market:           "usa",
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid market",
+			job: &ConversionJob{
+				JobID:            "job",
+				UserID:           "user",
+				SourcePlatform:   PlatformSpotify,
+				TargetPlatform:   PlatformYouTube,
+				SourcePlaylistID: "playlist",
+				Market:           "BR",
+			},
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -112,13 +136,16 @@ func TestConversion_StateTransitions(t *testing.T) {
 		t.Errorf("SourcePlaylistName = %q, want %q", conversion.SourcePlaylistName, "Source Playlist")
 	}
 
-	conversion.UpdateProgress(5, 4, 1)
-	if conversion.ProcessedTracks != 5 {
-		t.Errorf("ProcessedTracks = %d, want 5", conversion.ProcessedTracks)
+	conversion.UpdateProgress(6, 4, 1, 1)
+	if conversion.ProcessedTracks != 6 {
+		t.Errorf("ProcessedTracks = %d, want 6", conversion.ProcessedTracks)
 	}
 	if conversion.MatchedTracks != 4 {
 		t.Errorf("MatchedTracks = %d, want 4", conversion.MatchedTracks)
 	}
+	if conversion.ReviewTracks != 1 {
+		t.Errorf("ReviewTracks = %d, want 1", conversion.ReviewTracks)
+	}
 	if conversion.FailedTracks != 1 {
 		t.Errorf("FailedTracks = %d, want 1", conversion.FailedTracks)
 	}
@@ -158,6 +185,23 @@ func TestConversion_Fail(t *testing.T) {
 	}
 }
 
+func TestConversion_RetryKeepsTargetPlaylist(t *testing.T) {
+	job := NewConversionJob("user", PlatformSpotify, PlatformYouTube, "playlist", "My Playlist")
+	conversion, _ := NewConversion(job)
+
+	conversion.StartCreating()
+	conversion.AttachTargetPlaylist("yt-playlist-id", "https://youtube.com/playlist?list=xxx")
+	conversion.Fail("failed to add videos")
+	conversion.StartFetching()
+
+	if conversion.TargetPlaylistID != "yt-playlist-id" {
+		t.Errorf("TargetPlaylistID = %q, want %q", conversion.TargetPlaylistID, "yt-playlist-id")
+	}
+	if conversion.ErrorMessage != "" || conversion.CompletedAt != nil {
+		t.Errorf("expected retry to clear the failure, got %q/%v", conversion.ErrorMessage, conversion.CompletedAt)
+	}
+}
+
 func TestConversion_Progress(t *testing.T) {
 	job := NewConversionJob("user", PlatformSpotify, PlatformYouTube, "playlist", "My Playlist")
 	conversion, _ := NewConversion(job)
@@ -186,6 +230,7 @@ func TestConversionStatus_IsTerminal(t *testing.T) {
 		{ConversionStatusPending, false},
 		{ConversionStatusFetching, false},
 		{ConversionStatusMatching, false},
+		{ConversionStatusAwaitingReview, false},
 		{ConversionStatusCreating, false},
 		{ConversionStatusCompleted, true},
 		{ConversionStatusFailed, true},
@@ -199,3 +244,77 @@ func TestConversionStatus_IsTerminal(t *testing.T) {
 		})
 	}
 }
+
+func TestNewConversion_InvalidMatchOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		options MatchOptions
+		wantErr bool
+	}{
+		{"zero options", MatchOptions{}, false},
+		{"valid options", MatchOptions{MinConfidence: MatchConfidenceMedium, VideoPreference: VideoPreferenceOfficialAudio}, false},
+		{"invalid confidence", MatchOptions{MinConfidence: MatchConfidence("SOMETIMES")}, true},
+		{"invalid video preference", MatchOptions{VideoPreference: VideoPreference("SHORTS")}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := NewConversionJob("user", PlatformSpotify, PlatformYouTube, "playlist", "My Playlist")
+			job.MatchOptions = tt.options
+
+			_, err := NewConversion(job)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewConversion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConversionJob_JobType(t *testing.T) {
+	job := NewConversionJob("user", PlatformSpotify, PlatformYouTube, "playlist", "My Playlist")
+	if job.JobType() != JobTypeConvert {
+		t.Errorf("JobType() = %v, want %v", job.JobType(), JobTypeConvert)
+	}
+
+	review := NewReviewDecisionJob("conversion", "user", []ReviewDecision{{SourceTrackID: "sp1"}})
+	if review.JobType() != JobTypeReviewDecision {
+		t.Errorf("JobType() = %v, want %v", review.JobType(), JobTypeReviewDecision)
+	}
+	if !review.ReviewDecisions[0].IsRejection() {
+		t.Error("decision without target should be a rejection")
+	}
+}
+
+func TestConversion_Claim(t *testing.T) {
+	job := NewConversionJob("user", PlatformSpotify, PlatformYouTube, "playlist", "My Playlist")
+	conv, _ := NewConversion(job)
+
+	if conv.IsClaimed(time.Now()) {
+		t.Error("new conversion should not be claimed")
+	}
+
+	conv.Claim(time.Minute)
+	if !conv.IsClaimed(time.Now()) {
+		t.Error("expected conversion to be claimed")
+	}
+	if conv.IsClaimed(time.Now().Add(2 * time.Minute)) {
+		t.Error("expected the claim to expire with its lease")
+	}
+
+	conv.Fail("error")
+	if conv.IsClaimed(time.Now()) {
+		t.Error("a failed conversion should release its claim")
+	}
+}
+
+func TestConversion_PendingInserts(t *testing.T) {
+	job := NewConversionJob("user", PlatformSpotify, PlatformYouTube, "playlist", "My Playlist")
+	conv, _ := NewConversion(job)
+	conv.RecordInsertedVideos([]string{"yt1", "yt2"})
+
+	got := conv.PendingInserts([]string{"yt1", "yt2", "yt3", "yt1"})
+	want := []string{"yt3", "yt1"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("PendingInserts() = %v, want %v", got, want)
+	}
+}
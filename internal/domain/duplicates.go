@@ -0,0 +1,84 @@
+package domain
+
+import "strings"
+
+const duplicateDurationToleranceMs = 5000
+
+type DuplicatePolicy string
+
+const (
+	DuplicatePolicyKeep DuplicatePolicy = "KEEP"
+	DuplicatePolicyDrop DuplicatePolicy = "DROP"
+)
+
+func (p DuplicatePolicy) IsValid() bool {
+	switch p {
+	case "", DuplicatePolicyKeep, DuplicatePolicyDrop:
+		return true
+	default:
+		return false
+	}
+}
+
+type DuplicateTrack struct {
+	Track    *Track
+	Original *Track
+}
+
+func FindDuplicates(tracks []*Track) (unique []*Track, duplicates []DuplicateTrack) {
+	byISRC := make(map[string]*Track)
+	byTitle := make(map[string][]*Track)
+
+	for _, track := range tracks {
+		if original := findOriginal(track, byISRC, byTitle); original != nil {
+			duplicates = append(duplicates, DuplicateTrack{Track: track, Original: original})
+			continue
+		}
+
+		unique = append(unique, track)
+		if track.ISRC != "" {
+			byISRC[strings.ToUpper(track.ISRC)] = track
+		}
+		key := track.titleKey()
+		byTitle[key] = append(byTitle[key], track)
+	}
+
+	return unique, duplicates
+}
+
+func findOriginal(track *Track, byISRC map[string]*Track, byTitle map[string][]*Track) *Track {
+	if track.ISRC != "" {
+		if original, ok := byISRC[strings.ToUpper(track.ISRC)]; ok {
+			return original
+		}
+	}
+
+	for _, candidate := range byTitle[track.titleKey()] {
+		if sameRecording(track, candidate) {
+			return candidate
+		}
+	}
+	return nil
+}
+
+// sameRecording compares durations when both are known. Unknown durations are not
+// comparable, so only an exact title and artist count as the same recording.
+func sameRecording(a, b *Track) bool {
+	if a.DurationMs == 0 || b.DurationMs == 0 {
+		return strings.EqualFold(strings.TrimSpace(a.Name), strings.TrimSpace(b.Name)) &&
+			strings.EqualFold(strings.TrimSpace(a.Artist), strings.TrimSpace(b.Artist))
+	}
+	return durationsMatch(a.DurationMs, b.DurationMs)
+}
+
+func durationsMatch(a, b int) bool {
+	diff := a - b
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff <= duplicateDurationToleranceMs
+}
+
+func (t *Track) titleKey() string {
+	return NormalizeText(t.Name) + "|" + NormalizeText(t.Artist)
+}
@@ -0,0 +1,56 @@
+package domain
+
+import "testing"
+
+func TestFindDuplicates(t *testing.T) {
+	single, _ := NewTrack("Bohemian Rhapsody", "Queen", PlatformSpotify, "sp-single")
+	single.WithISRC("GBUM71029604").WithDuration(354000)
+	album, _ := NewTrack("Bohemian Rhapsody - Remastered 2011", "Queen", PlatformSpotify, "sp-album")
+	album.WithISRC("gbum71029604").WithDuration(355000)
+	sameTitle, _ := NewTrack("Bohemian Rhapsody", "QUEEN", PlatformSpotify, "sp-same-title")
+	sameTitle.WithDuration(356000)
+	liveVersion, _ := NewTrack("Bohemian Rhapsody", "Queen", PlatformSpotify, "sp-live")
+	liveVersion.WithDuration(420000)
+	other, _ := NewTrack("Radio Ga Ga", "Queen", PlatformSpotify, "sp-other")
+
+	unique, duplicates := FindDuplicates([]*Track{single, album, sameTitle, liveVersion, other})
+
+	if len(unique) != 3 || unique[0] != single || unique[1] != liveVersion || unique[2] != other {
+		t.Errorf("unexpected unique tracks %v", unique)
+	}
+	if len(duplicates) != 2 {
+		t.Fatalf("expected 2 duplicates, got %d", len(duplicates))
+	}
+	for _, duplicate := range duplicates {
+		if duplicate.Original != single {
+			t.Errorf("%s should be a duplicate of %s, got %s", duplicate.Track.PlatformID, single.PlatformID, duplicate.Original.PlatformID)
+		}
+	}
+}
+
+func TestFindDuplicates_UnknownDuration(t *testing.T) {
+	known, _ := NewTrack("Bohemian Rhapsody", "Queen", PlatformSpotify, "sp-known")
+	known.WithDuration(354000)
+	remaster, _ := NewTrack("Bohemian-Rhapsody", "Queen", PlatformSpotify, "sp-remaster")
+	exact, _ := NewTrack("bohemian rhapsody", "Queen", PlatformSpotify, "sp-exact")
+
+	unique, duplicates := FindDuplicates([]*Track{known, remaster, exact})
+
+	if len(unique) != 2 || unique[0] != known || unique[1] != remaster {
+		t.Errorf("expected a track without duration not to be deduped by a fuzzy title, got %v", unique)
+	}
+	if len(duplicates) != 1 || duplicates[0].Track != exact || duplicates[0].Original != known {
+		t.Errorf("expected only the exact title to be a duplicate, got %+v", duplicates)
+	}
+}
+
+func TestDuplicatePolicyIsValid(t *testing.T) {
+	for _, policy := range []DuplicatePolicy{"", DuplicatePolicyKeep, DuplicatePolicyDrop} {
+		if !policy.IsValid() {
+			t.Errorf("%q should be valid", policy)
+		}
+	}
+	if DuplicatePolicy("MERGE").IsValid() {
+		t.Error("MERGE should be invalid")
+	}
+}
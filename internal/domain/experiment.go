@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+)
+
+type ExperimentBucket string
+
+const (
+	ExperimentBucketUser ExperimentBucket = "USER"
+	ExperimentBucketJob  ExperimentBucket = "JOB"
+)
+
+func (b ExperimentBucket) IsValid() bool {
+	return b == ExperimentBucketUser || b == ExperimentBucketJob
+}
+
+type Variant struct {
+	Name       string
+	Weight     int
+	Strategies []string
+	ModelPath  string
+}
+
+// ParseVariant reads a "name:weight[:strategy|strategy...[:model]]" spec. An empty strategy list keeps
+// the default strategies, and the model is a scoring model file that replaces the default weights.
+func ParseVariant(spec string) (Variant, error) {
+	parts := strings.SplitN(strings.TrimSpace(spec), ":", 4)
+	if len(parts) < 2 {
+		return Variant{}, fmt.Errorf("invalid variant %q: expected name:weight[:strategies[:model]]", spec)
+	}
+
+	name := strings.TrimSpace(parts[0])
+	if name == "" {
+		return Variant{}, fmt.Errorf("invalid variant %q: name cannot be empty", spec)
+	}
+
+	weight, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+	if err != nil || weight <= 0 {
+		return Variant{}, fmt.Errorf("invalid variant %q: weight must be a positive integer", spec)
+	}
+
+	variant := Variant{Name: name, Weight: weight}
+	if len(parts) >= 3 {
+		for _, strategy := range strings.Split(parts[2], "|") {
+			if strategy = strings.TrimSpace(strategy); strategy != "" {
+				variant.Strategies = append(variant.Strategies, strategy)
+			}
+		}
+	}
+	if len(parts) == 4 {
+		variant.ModelPath = strings.TrimSpace(parts[3])
+	}
+	return variant, nil
+}
+
+type Experiment struct {
+	Name     string
+	BucketBy ExperimentBucket
+	Variants []Variant
+}
+
+func NewExperiment(name string, bucketBy ExperimentBucket, variants []Variant) (*Experiment, error) {
+	if name == "" {
+		return nil, errors.New("experiment name cannot be empty")
+	}
+	if !bucketBy.IsValid() {
+		return nil, fmt.Errorf("invalid experiment bucket %q", bucketBy)
+	}
+	if len(variants) == 0 {
+		return nil, errors.New("experiment needs at least one variant")
+	}
+
+	seen := make(map[string]bool, len(variants))
+	for _, v := range variants {
+		if v.Weight <= 0 {
+			return nil, fmt.Errorf("variant %q must have a positive weight", v.Name)
+		}
+		if seen[v.Name] {
+			return nil, fmt.Errorf("duplicate variant %q", v.Name)
+		}
+		seen[v.Name] = true
+	}
+
+	return &Experiment{Name: name, BucketBy: bucketBy, Variants: variants}, nil
+}
+
+// Assign picks a variant by hashing the bucketing key, so the same key always lands in the same variant.
+func (e *Experiment) Assign(job *ConversionJob) string {
+	key := job.UserID
+	if e.BucketBy == ExperimentBucketJob {
+		key = job.JobID
+	}
+
+	total := 0
+	for _, v := range e.Variants {
+		total += v.Weight
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(e.Name + ":" + key))
+	bucket := int(h.Sum32() % uint32(total))
+
+	for _, v := range e.Variants {
+		if bucket < v.Weight {
+			return v.Name
+		}
+		bucket -= v.Weight
+	}
+	return e.Variants[len(e.Variants)-1].Name
+}
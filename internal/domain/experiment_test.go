@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParseVariant(t *testing.T) {
+	tests := []struct {
+		spec       string
+		name       string
+		weight     int
+		strategies int
+		model      string
+		wantErr    bool
+	}{
+		{"control:50", "control", 50, 0, "", false},
+		{"no_fallback:25:mapping|isrc|search", "no_fallback", 25, 3, "", false},
+		{"learned:25::models/v2.json", "learned", 25, 0, "models/v2.json", false},
+		{"learned_isrc:25:isrc|search:models/v2.json", "learned_isrc", 25, 2, "models/v2.json", false},
+		{"control", "", 0, 0, "", true},
+		{":50", "", 0, 0, "", true},
+		{"control:0", "", 0, 0, "", true},
+		{"control:abc", "", 0, 0, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			v, err := ParseVariant(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseVariant(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if v.Name != tt.name || v.Weight != tt.weight || len(v.Strategies) != tt.strategies || v.ModelPath != tt.model {
+				t.Errorf("ParseVariant(%q) = %+v", tt.spec, v)
+			}
+		})
+	}
+}
+
+func TestNewExperiment_Validation(t *testing.T) {
+	if _, err := NewExperiment("", ExperimentBucketUser, []Variant{{Name: "a", Weight: 1}}); err == nil {
+		t.Error("expected error for empty name")
+	}
+	if _, err := NewExperiment("exp", "TEAM", []Variant{{Name: "a", Weight: 1}}); err == nil {
+		t.Error("expected error for invalid bucket")
+	}
+	if _, err := NewExperiment("exp", ExperimentBucketUser, nil); err == nil {
+		t.Error("expected error for no variants")
+	}
+	if _, err := NewExperiment("exp", ExperimentBucketUser, []Variant{{Name: "a", Weight: 1}, {Name: "a", Weight: 1}}); err == nil {
+		t.Error("expected error for duplicate variants")
+	}
+}
+
+func TestExperiment_AssignIsStablePerUser(t *testing.T) {
+	exp, _ := NewExperiment("scoring", ExperimentBucketUser, []Variant{{Name: "control", Weight: 50}, {Name: "treatment", Weight: 50}})
+
+	first := exp.Assign(&ConversionJob{UserID: "user-1", JobID: "job-1"})
+	for i := 0; i < 10; i++ {
+		job := &ConversionJob{UserID: "user-1", JobID: fmt.Sprintf("job-%d", i)}
+		if got := exp.Assign(job); got != first {
+			t.Fatalf("expected user to stay in %s, got %s", first, got)
+		}
+	}
+}
+
+func TestExperiment_AssignByJob(t *testing.T) {
+	exp, _ := NewExperiment("scoring", ExperimentBucketJob, []Variant{{Name: "control", Weight: 50}, {Name: "treatment", Weight: 50}})
+
+	seen := make(map[string]bool)
+	for i := 0; i < 50; i++ {
+		seen[exp.Assign(&ConversionJob{UserID: "user-1", JobID: fmt.Sprintf("job-%d", i)})] = true
+	}
+	if len(seen) != 2 {
+		t.Errorf("expected jobs of one user to spread across variants, got %v", seen)
+	}
+}
+
+func TestExperiment_AssignRespectsWeights(t *testing.T) {
+	exp, _ := NewExperiment("scoring", ExperimentBucketUser, []Variant{{Name: "control", Weight: 90}, {Name: "treatment", Weight: 10}})
+
+	counts := make(map[string]int)
+	for i := 0; i < 1000; i++ {
+		counts[exp.Assign(&ConversionJob{UserID: fmt.Sprintf("user-%d", i)})]++
+	}
+	if counts["treatment"] < 50 || counts["treatment"] > 150 {
+		t.Errorf("expected roughly 10%% treatment, got %v", counts)
+	}
+}
@@ -13,6 +13,9 @@ const (
 	StepMatchTrack           ConversionStep = "MATCH_TRACK"
 	StepCreateTargetPlaylist ConversionStep = "CREATE_TARGET_PLAYLIST"
 	StepAddTrackToPlaylist   ConversionStep = "ADD_TRACK_TO_PLAYLIST"
+	StepReviewTrack          ConversionStep = "REVIEW_TRACK"
+	StepSwapTrack            ConversionStep = "SWAP_TRACK"
+	StepDeduplicateTrack     ConversionStep = "DEDUPLICATE_TRACK"
 )
 
 type LogStatus string
@@ -21,20 +24,29 @@ const (
 	LogStatusSuccess LogStatus = "SUCCESS"
 	LogStatusFailed  LogStatus = "FAILED"
 	LogStatusSkipped LogStatus = "SKIPPED"
+
+	LogStatusNeedsReview LogStatus = "NEEDS_REVIEW"
 )
 
 type ConversionLog struct {
-	ID                string         `json:"id"`
-	ConversionID      string         `json:"conversionId"`
-	Step              ConversionStep `json:"step"`
-	Status            LogStatus      `json:"status"`
-	SourceTrackID     string         `json:"sourceTrackId,omitempty"`
-	SourceTrackName   string         `json:"sourceTrackName,omitempty"`
-	SourceTrackArtist string         `json:"sourceTrackArtist,omitempty"`
-	TargetTrackID     string         `json:"targetTrackId,omitempty"`
-	TargetTrackName   string         `json:"targetTrackName,omitempty"`
-	ErrorMessage      string         `json:"errorMessage,omitempty"`
-	CreatedAt         time.Time      `json:"createdAt"`
+	ID                string              `json:"id"`
+	ConversionID      string              `json:"conversionId"`
+	Step              ConversionStep      `json:"step"`
+	Status            LogStatus           `json:"status"`
+	SourceTrackID     string              `json:"sourceTrackId,omitempty"`
+	SourceTrackName   string              `json:"sourceTrackName,omitempty"`
+	SourceTrackArtist string              `json:"sourceTrackArtist,omitempty"`
+	TargetTrackID     string              `json:"targetTrackId,omitempty"`
+	TargetTrackName   string              `json:"targetTrackName,omitempty"`
+	PreviousTargetID  string              `json:"previousTargetId,omitempty"`
+	DuplicateOfID     string              `json:"duplicateOfId,omitempty"`
+	MatchMethod       string              `json:"matchMethod,omitempty"`
+	Confidence        MatchConfidence     `json:"confidence,omitempty"`
+	ScoreBreakdown    ScoreBreakdown      `json:"scoreBreakdown,omitempty"`
+	Rejected          []RejectedCandidate `json:"rejected,omitempty"`
+	Variant           string              `json:"variant,omitempty"`
+	ErrorMessage      string              `json:"errorMessage,omitempty"`
+	CreatedAt         time.Time           `json:"createdAt"`
 }
 
 func newConversionLog(conversionID string, step ConversionStep, status LogStatus) *ConversionLog {
@@ -70,6 +82,25 @@ func NewMatchTrackLog(conversionID string, sourceTrack *Track, targetTrack *Trac
 	return log
 }
 
+func (l *ConversionLog) WithMatchDetails(match *TrackMatch) *ConversionLog {
+	l.MatchMethod = match.MatchMethod
+	l.Confidence = match.Confidence
+	l.ScoreBreakdown = match.ScoreBreakdown
+	l.Rejected = match.Rejected
+	return l
+}
+
+func (l *ConversionLog) WithVariant(variant string) *ConversionLog {
+	l.Variant = variant
+	return l
+}
+
+func NewMatchTrackReviewLog(conversionID string, sourceTrack *Track, targetTrack *Track, reason string) *ConversionLog {
+	log := NewMatchTrackLog(conversionID, sourceTrack, targetTrack, LogStatusNeedsReview)
+	log.ErrorMessage = reason
+	return log
+}
+
 func NewMatchTrackErrorLog(conversionID string, sourceTrack *Track, errorMessage string) *ConversionLog {
 	log := NewMatchTrackLog(conversionID, sourceTrack, nil, LogStatusFailed)
 	log.ErrorMessage = errorMessage
@@ -93,3 +124,51 @@ func NewAddTrackLog(conversionID string, targetTrack *Track, status LogStatus, e
 	log.ErrorMessage = errorMessage
 	return log
 }
+
+func NewReviewTrackLog(conversionID string, sourceTrack *Track, targetTrack *Track, status LogStatus) *ConversionLog {
+	log := newConversionLog(conversionID, StepReviewTrack, status)
+
+	if sourceTrack != nil {
+		log.SourceTrackID = sourceTrack.PlatformID
+		log.SourceTrackName = sourceTrack.Name
+		log.SourceTrackArtist = sourceTrack.Artist
+	}
+
+	if targetTrack != nil {
+		log.TargetTrackID = targetTrack.PlatformID
+		log.TargetTrackName = targetTrack.Name
+	}
+
+	return log
+}
+
+func NewSwapTrackLog(conversionID string, sourceTrack *Track, previousTarget *Track, targetTrack *Track, status LogStatus, errorMessage string) *ConversionLog {
+	log := newConversionLog(conversionID, StepSwapTrack, status)
+
+	if sourceTrack != nil {
+		log.SourceTrackID = sourceTrack.PlatformID
+		log.SourceTrackName = sourceTrack.Name
+		log.SourceTrackArtist = sourceTrack.Artist
+	}
+
+	if previousTarget != nil {
+		log.PreviousTargetID = previousTarget.PlatformID
+	}
+
+	if targetTrack != nil {
+		log.TargetTrackID = targetTrack.PlatformID
+		log.TargetTrackName = targetTrack.Name
+	}
+
+	log.ErrorMessage = errorMessage
+	return log
+}
+
+func NewDuplicateTrackLog(conversionID string, duplicate DuplicateTrack, status LogStatus) *ConversionLog {
+	log := newConversionLog(conversionID, StepDeduplicateTrack, status)
+	log.SourceTrackID = duplicate.Track.PlatformID
+	log.SourceTrackName = duplicate.Track.Name
+	log.SourceTrackArtist = duplicate.Track.Artist
+	log.DuplicateOfID = duplicate.Original.PlatformID
+	return log
+}
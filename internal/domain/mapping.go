@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+type MappingVote string
+
+const (
+	MappingVoteMatch      MappingVote = "MATCH"
+	MappingVoteCorrection MappingVote = "CORRECTION"
+)
+
+const (
+	correctionVoteWeight = 5
+	minMappingVotes      = 3
+)
+
+type TrackMapping struct {
+	SourcePlatform     Platform      `json:"sourcePlatform"`
+	SourcePlatformID   string        `json:"sourcePlatformId"`
+	TargetPlatform     Platform      `json:"targetPlatform"`
+	TargetPlatformID   string        `json:"targetPlatformId"`
+	TargetName         string        `json:"targetName"`
+	TargetArtist       string        `json:"targetArtist"`
+	TargetChannelID    string        `json:"targetChannelId,omitempty"`
+	TargetExplicit     bool          `json:"targetExplicit,omitempty"`
+	TargetAvailability *Availability `json:"targetAvailability,omitempty"`
+	MatchVotes         int           `json:"matchVotes"`
+	CorrectionVotes    int           `json:"correctionVotes"`
+	UpdatedAt          time.Time     `json:"updatedAt"`
+}
+
+func NewTrackMapping(source *Track, target *Track) (*TrackMapping, error) {
+	if source == nil || target == nil {
+		return nil, errors.New("source and target tracks are required")
+	}
+	if source.PlatformID == "" || target.PlatformID == "" {
+		return nil, errors.New("platform IDs cannot be empty")
+	}
+	if !source.Platform.IsValid() || !target.Platform.IsValid() {
+		return nil, errors.New("invalid platform")
+	}
+
+	return &TrackMapping{
+		SourcePlatform:     source.Platform,
+		SourcePlatformID:   source.PlatformID,
+		TargetPlatform:     target.Platform,
+		TargetPlatformID:   target.PlatformID,
+		TargetName:         target.Name,
+		TargetArtist:       target.Artist,
+		TargetChannelID:    target.ChannelID,
+		TargetExplicit:     target.Explicit,
+		TargetAvailability: target.Availability,
+		UpdatedAt:          time.Now(),
+	}, nil
+}
+
+func (m *TrackMapping) Score() int {
+	return m.MatchVotes + m.CorrectionVotes*correctionVoteWeight
+}
+
+func (m *TrackMapping) Votes() int {
+	return m.MatchVotes + m.CorrectionVotes
+}
+
+// IsConfirmed reports whether enough separate votes back the mapping to reuse it without searching.
+// A correction outweighs match votes when mappings compete, but one correction alone confirms nothing.
+func (m *TrackMapping) IsConfirmed() bool {
+	return m.Votes() >= minMappingVotes && m.Score() > 0
+}
+
+func (m *TrackMapping) TargetTrack() *Track {
+	return &Track{
+		Name:         m.TargetName,
+		Artist:       m.TargetArtist,
+		ChannelID:    m.TargetChannelID,
+		Explicit:     m.TargetExplicit,
+		Availability: m.TargetAvailability,
+		Platform:     m.TargetPlatform,
+		PlatformID:   m.TargetPlatformID,
+	}
+}
+
+// BestMapping returns the highest scoring mapping, or nil when two targets share the top score.
+func BestMapping(mappings []*TrackMapping) *TrackMapping {
+	var best *TrackMapping
+	tied := false
+	for _, m := range mappings {
+		switch {
+		case best == nil || m.Score() > best.Score():
+			best, tied = m, false
+		case m.Score() == best.Score():
+			tied = true
+		}
+	}
+	if tied {
+		return nil
+	}
+	return best
+}
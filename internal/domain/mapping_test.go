@@ -0,0 +1,65 @@
+package domain
+
+import "testing"
+
+func TestNewTrackMapping(t *testing.T) {
+	source, _ := NewTrack("Bohemian Rhapsody", "Queen", PlatformSpotify, "sp1")
+	target, _ := NewTrack("Bohemian Rhapsody", "Queen - Topic", PlatformYouTube, "yt1")
+	target.WithChannelID("UC-queen")
+
+	mapping, err := NewTrackMapping(source, target)
+	if err != nil {
+		t.Fatalf("NewTrackMapping() error: %v", err)
+	}
+	if mapping.SourcePlatformID != "sp1" || mapping.TargetPlatformID != "yt1" {
+		t.Errorf("mapping = %s -> %s, want sp1 -> yt1", mapping.SourcePlatformID, mapping.TargetPlatformID)
+	}
+	if mapped := mapping.TargetTrack(); mapped.ChannelID != "UC-queen" {
+		t.Errorf("mapped channel ID = %q, want UC-queen", mapped.ChannelID)
+	}
+
+	if _, err := NewTrackMapping(source, nil); err == nil {
+		t.Error("expected error for nil target")
+	}
+}
+
+func TestBestMapping(t *testing.T) {
+	popular := &TrackMapping{TargetPlatformID: "popular", MatchVotes: 4}
+	corrected := &TrackMapping{TargetPlatformID: "corrected", MatchVotes: 0, CorrectionVotes: 1}
+	unpopular := &TrackMapping{TargetPlatformID: "unpopular", MatchVotes: 1}
+
+	best := BestMapping([]*TrackMapping{popular, corrected, unpopular})
+	if best.TargetPlatformID != "corrected" {
+		t.Errorf("BestMapping() = %s, want corrected", best.TargetPlatformID)
+	}
+
+	if BestMapping(nil) != nil {
+		t.Error("BestMapping(nil) should be nil")
+	}
+
+	rival := &TrackMapping{TargetPlatformID: "rival", MatchVotes: 5}
+	if best := BestMapping([]*TrackMapping{popular, corrected, rival}); best != nil {
+		t.Errorf("BestMapping() = %s, want nil for a tie", best.TargetPlatformID)
+	}
+}
+
+func TestTrackMapping_IsConfirmed(t *testing.T) {
+	tests := []struct {
+		name    string
+		mapping TrackMapping
+		want    bool
+	}{
+		{"single correction", TrackMapping{CorrectionVotes: 1}, false},
+		{"two match votes", TrackMapping{MatchVotes: 2}, false},
+		{"three match votes", TrackMapping{MatchVotes: 3}, true},
+		{"correction and match votes", TrackMapping{MatchVotes: 2, CorrectionVotes: 1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.mapping.IsConfirmed(); got != tt.want {
+				t.Errorf("IsConfirmed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
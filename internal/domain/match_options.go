@@ -0,0 +1,94 @@
+package domain
+
+import "errors"
+
+type VideoPreference string
+
+const (
+	VideoPreferenceAny           VideoPreference = ""
+	VideoPreferenceOfficialAudio VideoPreference = "OFFICIAL_AUDIO"
+	VideoPreferenceMusicVideo    VideoPreference = "MUSIC_VIDEO"
+)
+
+func (p VideoPreference) IsValid() bool {
+	switch p {
+	case VideoPreferenceAny, VideoPreferenceOfficialAudio, VideoPreferenceMusicVideo:
+		return true
+	default:
+		return false
+	}
+}
+
+type ExplicitPreference string
+
+const (
+	ExplicitPreferenceSource   ExplicitPreference = ""
+	ExplicitPreferenceExplicit ExplicitPreference = "EXPLICIT"
+	ExplicitPreferenceClean    ExplicitPreference = "CLEAN"
+)
+
+func (p ExplicitPreference) IsValid() bool {
+	switch p {
+	case ExplicitPreferenceSource, ExplicitPreferenceExplicit, ExplicitPreferenceClean:
+		return true
+	default:
+		return false
+	}
+}
+
+type VersionPreference string
+
+const (
+	VersionPreferenceSource     VersionPreference = ""
+	VersionPreferenceOriginal   VersionPreference = "ORIGINAL"
+	VersionPreferenceRemastered VersionPreference = "REMASTERED"
+)
+
+func (p VersionPreference) IsValid() bool {
+	switch p {
+	case VersionPreferenceSource, VersionPreferenceOriginal, VersionPreferenceRemastered:
+		return true
+	default:
+		return false
+	}
+}
+
+type MatchOptions struct {
+	MinConfidence   MatchConfidence    `json:"minConfidence,omitempty"`
+	ExcludeTerms    []string           `json:"excludeTerms,omitempty"`
+	PreferTerms     []string           `json:"preferTerms,omitempty"`
+	AllowLive       bool               `json:"allowLive,omitempty"`
+	AllowRemix      bool               `json:"allowRemix,omitempty"`
+	VideoPreference VideoPreference    `json:"videoPreference,omitempty"`
+	Explicit        ExplicitPreference `json:"explicit,omitempty"`
+	Version         VersionPreference  `json:"version,omitempty"`
+	RequireReview   bool               `json:"requireReview,omitempty"`
+	Duplicates      DuplicatePolicy    `json:"duplicates,omitempty"`
+	Market          string             `json:"-"`
+}
+
+func (o MatchOptions) Validate() error {
+	if o.MinConfidence != "" && !o.MinConfidence.IsValid() {
+		return errors.New("invalid minimum confidence")
+	}
+	if !o.VideoPreference.IsValid() {
+		return errors.New("invalid video preference")
+	}
+	if !o.Explicit.IsValid() {
+		return errors.New("invalid explicit preference")
+	}
+	if !o.Version.IsValid() {
+		return errors.New("invalid version preference")
+	}
+	if !o.Duplicates.IsValid() {
+		return errors.New("invalid duplicate policy")
+	}
+	return nil
+}
+
+func (o MatchOptions) EffectiveMinConfidence() MatchConfidence {
+	if o.MinConfidence == "" {
+		return MatchConfidenceLow
+	}
+	return o.MinConfidence
+}
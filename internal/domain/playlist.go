@@ -73,3 +73,13 @@ func (p *Playlist) GetTrackIDs() []string {
 	}
 	return ids
 }
+
+// VideoInsertResult is the outcome of adding one video to a target playlist.
+type VideoInsertResult struct {
+	VideoID string `json:"videoId"`
+	Error   string `json:"error,omitempty"`
+}
+
+func (r VideoInsertResult) Failed() bool {
+	return r.Error != ""
+}
@@ -0,0 +1,55 @@
+package domain
+
+import "time"
+
+type ScoreBreakdown map[string]float64
+
+func (b ScoreBreakdown) Total() float64 {
+	total := 0.0
+	for _, value := range b {
+		total += value
+	}
+	return total
+}
+
+// ScoringWeights scales each feature of a breakdown. Features without a weight were not trained and do not count.
+type ScoringWeights map[string]float64
+
+func (w ScoringWeights) Score(b ScoreBreakdown) float64 {
+	if len(w) == 0 {
+		return b.Total()
+	}
+
+	total := 0.0
+	for feature, value := range b {
+		total += value * w[feature]
+	}
+	return total
+}
+
+type ScoringModel struct {
+	Features  []string       `json:"features"`
+	Weights   ScoringWeights `json:"weights"`
+	Bias      float64        `json:"bias"`
+	Examples  int            `json:"examples"`
+	TrainedAt time.Time      `json:"trainedAt"`
+}
+
+type RejectedCandidate struct {
+	PlatformID string         `json:"platformId"`
+	Name       string         `json:"name"`
+	Artist     string         `json:"artist"`
+	Score      float64        `json:"score"`
+	Breakdown  ScoreBreakdown `json:"breakdown,omitempty"`
+	Reason     string         `json:"reason"`
+}
+
+func NewRejectedCandidate(track *Track, score float64, reason string) RejectedCandidate {
+	return RejectedCandidate{
+		PlatformID: track.PlatformID,
+		Name:       track.Name,
+		Artist:     track.Artist,
+		Score:      score,
+		Reason:     reason,
+	}
+}
@@ -0,0 +1,25 @@
+package domain
+
+import "testing"
+
+func TestScoringWeights_Score(t *testing.T) {
+	breakdown := ScoreBreakdown{"confidence": 30, "official_channel": 2}
+
+	tests := []struct {
+		name    string
+		weights ScoringWeights
+		want    float64
+	}{
+		{"no weights sums the breakdown", nil, 32},
+		{"weighted features", ScoringWeights{"confidence": 0.1, "official_channel": 0.5}, 4},
+		{"untrained feature counts zero", ScoringWeights{"confidence": 0.1}, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.weights.Score(breakdown); got != tt.want {
+				t.Errorf("Score() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,19 +2,37 @@ package domain
 
 import (
 	"errors"
+	"strconv"
+	"strings"
+	"unicode"
 
 	"github.com/google/uuid"
 )
 
+// ResultKind tells a YouTube Music "song" (auto-generated audio) apart from a regular video.
+type ResultKind string
+
+const (
+	ResultKindUnknown ResultKind = ""
+	ResultKindSong    ResultKind = "SONG"
+	ResultKindVideo   ResultKind = "VIDEO"
+)
+
 type Track struct {
-	ID         string   `json:"id"`
-	Name       string   `json:"name"`
-	Artist     string   `json:"artist"`
-	Album      string   `json:"album"`
-	DurationMs int      `json:"durationMs"`
-	ISRC       string   `json:"isrc,omitempty"`
-	Platform   Platform `json:"platform"`
-	PlatformID string   `json:"platformId"`
+	ID              string        `json:"id"`
+	Name            string        `json:"name"`
+	Artist          string        `json:"artist"`
+	ChannelID       string        `json:"channelId,omitempty"`
+	Album           string        `json:"album"`
+	DurationMs      int           `json:"durationMs"`
+	ISRC            string        `json:"isrc,omitempty"`
+	Artists         []string      `json:"artists,omitempty"`
+	Explicit        bool          `json:"explicit,omitempty"`
+	Availability    *Availability `json:"availability,omitempty"`
+	Kind            ResultKind    `json:"kind,omitempty"`
+	OfficialChannel bool          `json:"officialChannel,omitempty"`
+	Platform        Platform      `json:"platform"`
+	PlatformID      string        `json:"platformId"`
 }
 
 func NewTrack(name, artist string, platform Platform, platformID string) (*Track, error) {
@@ -55,6 +73,61 @@ func (t *Track) WithISRC(isrc string) *Track {
 	return t
 }
 
+func (t *Track) WithArtists(artists []string) *Track {
+	t.Artists = artists
+	return t
+}
+
+func (t *Track) WithExplicit(explicit bool) *Track {
+	t.Explicit = explicit
+	return t
+}
+
+func (t *Track) WithAvailability(availability *Availability) *Track {
+	t.Availability = availability
+	return t
+}
+
+func (t *Track) WithKind(kind ResultKind) *Track {
+	t.Kind = kind
+	return t
+}
+
+// WithChannelID sets the ID of the YouTube channel that uploaded the video.
+// Artist holds the channel's display title, which its owner can change.
+func (t *Track) WithChannelID(channelID string) *Track {
+	t.ChannelID = channelID
+	return t
+}
+
+func (t *Track) WithOfficialChannel(official bool) *Track {
+	t.OfficialChannel = official
+	return t
+}
+
+func (t *Track) Fingerprint() string {
+	return NormalizeText(t.Name) + "|" + NormalizeText(t.Artist) + "|" + strconv.Itoa(t.DurationMs/fingerprintDurationBucketMs)
+}
+
+const fingerprintDurationBucketMs = 5000
+
+func NormalizeText(s string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if space && b.Len() > 0 {
+				b.WriteRune(' ')
+			}
+			b.WriteRune(r)
+			space = false
+		} else {
+			space = true
+		}
+	}
+	return b.String()
+}
+
 type MatchConfidence string
 
 const (
@@ -64,12 +137,53 @@ const (
 	MatchConfidenceNone   MatchConfidence = "NONE"
 )
 
+func (c MatchConfidence) IsValid() bool {
+	switch c {
+	case MatchConfidenceHigh, MatchConfidenceMedium, MatchConfidenceLow, MatchConfidenceNone:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c MatchConfidence) Rank() int {
+	switch c {
+	case MatchConfidenceHigh:
+		return 3
+	case MatchConfidenceMedium:
+		return 2
+	case MatchConfidenceLow:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (c MatchConfidence) AtLeast(other MatchConfidence) bool {
+	return c.Rank() >= other.Rank()
+}
+
+func ParseMatchConfidence(s string) (MatchConfidence, bool) {
+	c := MatchConfidence(s)
+	return c, c.IsValid()
+}
+
+const (
+	MatchMethodUserReview = "user_review"
+	MatchMethodUserSwap   = "user_swap"
+)
+
 type TrackMatch struct {
-	SourceTrack *Track          `json:"sourceTrack"`
-	TargetTrack *Track          `json:"targetTrack,omitempty"`
-	Confidence  MatchConfidence `json:"confidence"`
-	MatchMethod string          `json:"matchMethod,omitempty"`
-	Error       string          `json:"error,omitempty"`
+	SourceTrack    *Track              `json:"sourceTrack"`
+	TargetTrack    *Track              `json:"targetTrack,omitempty"`
+	Confidence     MatchConfidence     `json:"confidence"`
+	MatchMethod    string              `json:"matchMethod,omitempty"`
+	NeedsReview    bool                `json:"needsReview,omitempty"`
+	Candidates     []*Track            `json:"candidates,omitempty"`
+	ScoreBreakdown ScoreBreakdown      `json:"scoreBreakdown,omitempty"`
+	Rejected       []RejectedCandidate `json:"rejected,omitempty"`
+	Error          string              `json:"error,omitempty"`
+	SearchFailed   bool                `json:"searchFailed,omitempty"`
 }
 
 func NewTrackMatch(source *Track, target *Track, confidence MatchConfidence, method string) *TrackMatch {
@@ -81,6 +195,53 @@ func NewTrackMatch(source *Track, target *Track, confidence MatchConfidence, met
 	}
 }
 
+func (m *TrackMatch) IsMatched() bool {
+	return m.Confidence != MatchConfidenceNone && !m.NeedsReview
+}
+
+func (m *TrackMatch) MarkNeedsReview(reason string) {
+	m.NeedsReview = true
+	m.Error = reason
+}
+
+func (m *TrackMatch) FindCandidate(platformID string) *Track {
+	if m.TargetTrack != nil && m.TargetTrack.PlatformID == platformID {
+		return m.TargetTrack
+	}
+	for _, candidate := range m.Candidates {
+		if candidate.PlatformID == platformID {
+			return candidate
+		}
+	}
+	return nil
+}
+
+func (m *TrackMatch) ApplyReview(target *Track) {
+	m.TargetTrack = target
+	m.Confidence = MatchConfidenceHigh
+	m.MatchMethod = MatchMethodUserReview
+	m.NeedsReview = false
+	m.Error = ""
+}
+
+func (m *TrackMatch) ApplySwap(target *Track) {
+	m.ApplyReview(target)
+	m.MatchMethod = MatchMethodUserSwap
+}
+
+func (m *TrackMatch) ForDuplicate(source *Track) *TrackMatch {
+	duplicate := *m
+	duplicate.SourceTrack = source
+	return &duplicate
+}
+
+func (m *TrackMatch) RejectReview() {
+	m.TargetTrack = nil
+	m.Confidence = MatchConfidenceNone
+	m.NeedsReview = false
+	m.Error = "rejected during review"
+}
+
 func NewFailedMatch(source *Track, err string) *TrackMatch {
 	return &TrackMatch{
 		SourceTrack: source,
@@ -88,3 +249,11 @@ func NewFailedMatch(source *Track, err string) *TrackMatch {
 		Error:       err,
 	}
 }
+
+// NewSearchFailedMatch records a track whose lookups failed, for example on a quota or network error.
+// Unlike a confirmed "no match found", the result is unknown and the track is worth searching again.
+func NewSearchFailedMatch(source *Track, err error) *TrackMatch {
+	match := NewFailedMatch(source, err.Error())
+	match.SearchFailed = true
+	return match
+}
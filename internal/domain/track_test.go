@@ -147,3 +147,60 @@ func TestNewFailedMatch(t *testing.T) {
 		t.Errorf("match.Error = %q, want %q", match.Error, "no match found")
 	}
 }
+
+func TestMatchConfidence_AtLeast(t *testing.T) {
+	tests := []struct {
+		confidence MatchConfidence
+		minimum    MatchConfidence
+		want       bool
+	}{
+		{MatchConfidenceHigh, MatchConfidenceMedium, true},
+		{MatchConfidenceMedium, MatchConfidenceMedium, true},
+		{MatchConfidenceLow, MatchConfidenceMedium, false},
+		{MatchConfidenceNone, MatchConfidenceLow, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.confidence)+">="+string(tt.minimum), func(t *testing.T) {
+			if got := tt.confidence.AtLeast(tt.minimum); got != tt.want {
+				t.Errorf("AtLeast() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTrack_Fingerprint(t *testing.T) {
+	a, _ := NewTrack("Bohemian Rhapsody", "Queen", PlatformSpotify, "sp1")
+	a.WithDuration(354000)
+	b, _ := NewTrack("  bohemian   RHAPSODY!", "queen", PlatformSpotify, "sp2")
+	b.WithDuration(354320)
+	c, _ := NewTrack("Bohemian Rhapsody", "Queen", PlatformSpotify, "sp3")
+	c.WithDuration(180000)
+
+	if a.Fingerprint() != b.Fingerprint() {
+		t.Errorf("expected equal fingerprints, got %q and %q", a.Fingerprint(), b.Fingerprint())
+	}
+	if a.Fingerprint() == c.Fingerprint() {
+		t.Errorf("expected different fingerprints for different durations, got %q", a.Fingerprint())
+	}
+}
+
+func TestNormalizeText(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"Bohemian Rhapsody", "bohemian rhapsody"},
+		{"  AC/DC - Back in Black ", "ac dc back in black"},
+		{"Beyoncé", "beyoncé"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := NormalizeText(tt.input); got != tt.want {
+				t.Errorf("NormalizeText(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
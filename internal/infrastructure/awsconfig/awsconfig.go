@@ -0,0 +1,36 @@
+package awsconfig
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+
+	appconfig "github.com/marcelovmendes/playswap/conversion-worker/internal/config"
+)
+
+// Load builds the AWS SDK config, pointing every client at a local endpoint such as LocalStack when one is set.
+func Load(ctx context.Context, awsCfg appconfig.AWSConfig) (aws.Config, error) {
+	opts := []func(*config.LoadOptions) error{
+		config.WithRegion(awsCfg.Region),
+	}
+
+	if awsCfg.Endpoint != "" {
+		opts = append(opts,
+			config.WithEndpointResolverWithOptions(
+				aws.EndpointResolverWithOptionsFunc(func(service, region string, options ...interface{}) (aws.Endpoint, error) {
+					return aws.Endpoint{
+						PartitionID:   "aws",
+						URL:           awsCfg.Endpoint,
+						SigningRegion: region,
+						SigningMethod: "v4",
+					}, nil
+				}),
+			),
+			config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider("test", "test", "")),
+		)
+	}
+
+	return config.LoadDefaultConfig(ctx, opts...)
+}
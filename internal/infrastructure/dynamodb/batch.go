@@ -0,0 +1,62 @@
+package dynamodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+const (
+	batchWriteSize       = 25
+	batchWriteAttempts   = 5
+	batchWriteBaseDelay  = 50 * time.Millisecond
+	batchWriteMaxBackoff = 2 * time.Second
+)
+
+type batchWriter interface {
+	BatchWriteItem(ctx context.Context, params *dynamodb.BatchWriteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error)
+}
+
+// batchWrite writes the requests in batches of 25. DynamoDB returns throttled writes as unprocessed
+// items instead of failing the call, so those are retried with backoff until none are left.
+func batchWrite(ctx context.Context, client batchWriter, tableName string, requests []types.WriteRequest) error {
+	for i := 0; i < len(requests); i += batchWriteSize {
+		end := min(i+batchWriteSize, len(requests))
+		if err := writeWithRetry(ctx, client, tableName, requests[i:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeWithRetry(ctx context.Context, client batchWriter, tableName string, pending []types.WriteRequest) error {
+	delay := batchWriteBaseDelay
+	for attempt := 1; ; attempt++ {
+		out, err := client.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{
+			RequestItems: map[string][]types.WriteRequest{
+				tableName: pending,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		pending = out.UnprocessedItems[tableName]
+		if len(pending) == 0 {
+			return nil
+		}
+		if attempt == batchWriteAttempts {
+			return fmt.Errorf("%d items still unprocessed after %d attempts", len(pending), attempt)
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		delay = min(delay*2, batchWriteMaxBackoff)
+	}
+}
@@ -0,0 +1,80 @@
+package dynamodb
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// throttlingWriter leaves the last item of every call unprocessed until it has been throttled the given number of times.
+type throttlingWriter struct {
+	throttles int
+	calls     int
+	written   int
+}
+
+func (w *throttlingWriter) BatchWriteItem(ctx context.Context, params *dynamodb.BatchWriteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error) {
+	w.calls++
+	out := &dynamodb.BatchWriteItemOutput{}
+	for table, requests := range params.RequestItems {
+		if len(requests) > batchWriteSize {
+			return nil, fmt.Errorf("batch of %d requests exceeds the limit", len(requests))
+		}
+		if w.throttles > 0 {
+			w.throttles--
+			w.written += len(requests) - 1
+			out.UnprocessedItems = map[string][]types.WriteRequest{table: requests[len(requests)-1:]}
+			continue
+		}
+		w.written += len(requests)
+	}
+	return out, nil
+}
+
+func writeRequests(n int) []types.WriteRequest {
+	requests := make([]types.WriteRequest, n)
+	for i := range requests {
+		requests[i] = types.WriteRequest{PutRequest: &types.PutRequest{Item: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberN{Value: fmt.Sprint(i)},
+		}}}
+	}
+	return requests
+}
+
+func TestBatchWrite_RetriesUnprocessedItems(t *testing.T) {
+	writer := &throttlingWriter{throttles: 2}
+
+	if err := batchWrite(context.Background(), writer, "matches", writeRequests(30)); err != nil {
+		t.Fatalf("batchWrite() error: %v", err)
+	}
+	if writer.written != 30 {
+		t.Errorf("expected 30 items written, got %d", writer.written)
+	}
+	if writer.calls != 4 {
+		t.Errorf("expected 2 batches and 2 retries, got %d calls", writer.calls)
+	}
+}
+
+func TestBatchWrite_FailsWhenItemsRemainUnprocessed(t *testing.T) {
+	writer := &throttlingWriter{throttles: batchWriteAttempts}
+
+	if err := batchWrite(context.Background(), writer, "matches", writeRequests(3)); err == nil {
+		t.Fatal("expected an error when items stay unprocessed")
+	}
+	if writer.calls != batchWriteAttempts {
+		t.Errorf("expected %d attempts, got %d", batchWriteAttempts, writer.calls)
+	}
+}
+
+func TestBatchWrite_StopsWhenContextIsDone(t *testing.T) {
+	writer := &throttlingWriter{throttles: batchWriteAttempts}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := batchWrite(ctx, writer, "matches", writeRequests(3)); err != context.Canceled {
+		t.Errorf("batchWrite() error = %v, want context.Canceled", err)
+	}
+}
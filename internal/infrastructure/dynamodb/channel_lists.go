@@ -0,0 +1,123 @@
+package dynamodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/application"
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/domain"
+)
+
+type channelListItem struct {
+	Scope     string                   `dynamodbav:"scope"`
+	EntryKey  string                   `dynamodbav:"entryKey"`
+	Kind      domain.ChannelListKind   `dynamodbav:"kind"`
+	Target    domain.ChannelListTarget `dynamodbav:"target"`
+	Value     string                   `dynamodbav:"value"`
+	Note      string                   `dynamodbav:"note,omitempty"`
+	CreatedAt string                   `dynamodbav:"createdAt"`
+}
+
+type channelListRepository struct {
+	client    *dynamodb.Client
+	tableName string
+}
+
+func NewChannelListRepository(cfg aws.Config, tableName string) application.ChannelListRepository {
+	return &channelListRepository{
+		client:    dynamodb.NewFromConfig(cfg),
+		tableName: tableName,
+	}
+}
+
+func (r *channelListRepository) ListByScope(ctx context.Context, scope string) ([]*domain.ChannelListEntry, error) {
+	paginator := dynamodb.NewQueryPaginator(r.client, &dynamodb.QueryInput{
+		TableName:              &r.tableName,
+		KeyConditionExpression: aws.String("#scope = :scope"),
+		ExpressionAttributeNames: map[string]string{
+			"#scope": "scope",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":scope": &types.AttributeValueMemberS{Value: scope},
+		},
+	})
+
+	var entries []*domain.ChannelListEntry
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query channel lists: %w", err)
+		}
+
+		var items []channelListItem
+		if err := attributevalue.UnmarshalListOfMaps(page.Items, &items); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal channel lists: %w", err)
+		}
+
+		for _, item := range items {
+			entries = append(entries, fromChannelListItem(item))
+		}
+	}
+
+	return entries, nil
+}
+
+func (r *channelListRepository) Put(ctx context.Context, entry *domain.ChannelListEntry) error {
+	av, err := attributevalue.MarshalMap(toChannelListItem(entry))
+	if err != nil {
+		return fmt.Errorf("failed to marshal channel list entry: %w", err)
+	}
+
+	_, err = r.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: &r.tableName,
+		Item:      av,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save channel list entry: %w", err)
+	}
+	return nil
+}
+
+func (r *channelListRepository) Delete(ctx context.Context, entry *domain.ChannelListEntry) error {
+	_, err := r.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: &r.tableName,
+		Key: map[string]types.AttributeValue{
+			"scope":    &types.AttributeValueMemberS{Value: entry.Scope},
+			"entryKey": &types.AttributeValueMemberS{Value: entry.Key()},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete channel list entry: %w", err)
+	}
+	return nil
+}
+
+func toChannelListItem(e *domain.ChannelListEntry) channelListItem {
+	return channelListItem{
+		Scope:     e.Scope,
+		EntryKey:  e.Key(),
+		Kind:      e.Kind,
+		Target:    e.Target,
+		Value:     e.Value,
+		Note:      e.Note,
+		CreatedAt: e.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func fromChannelListItem(item channelListItem) *domain.ChannelListEntry {
+	createdAt, _ := time.Parse(time.RFC3339, item.CreatedAt)
+	return &domain.ChannelListEntry{
+		Scope:     item.Scope,
+		Kind:      item.Kind,
+		Target:    item.Target,
+		Value:     item.Value,
+		Note:      item.Note,
+		CreatedAt: createdAt,
+	}
+}
@@ -2,35 +2,46 @@ package dynamodb
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 
 	"github.com/marcelovmendes/playswap/conversion-worker/internal/application"
 	"github.com/marcelovmendes/playswap/conversion-worker/internal/domain"
 )
 
 type conversionItem struct {
-	ID                 string                   `dynamodbav:"id"`
-	UserID             string                   `dynamodbav:"userId"`
-	SourcePlatform     domain.Platform          `dynamodbav:"sourcePlatform"`
-	TargetPlatform     domain.Platform          `dynamodbav:"targetPlatform"`
-	SourcePlaylistID   string                   `dynamodbav:"sourcePlaylistId"`
-	SourcePlaylistName string                   `dynamodbav:"sourcePlaylistName,omitempty"`
-	TargetPlaylistID   string                   `dynamodbav:"targetPlaylistId,omitempty"`
-	TargetPlaylistURL  string                   `dynamodbav:"targetPlaylistUrl,omitempty"`
-	TargetPlaylistName string                   `dynamodbav:"targetPlaylistName,omitempty"`
-	Status             domain.ConversionStatus  `dynamodbav:"status"`
-	TotalTracks        int                      `dynamodbav:"totalTracks"`
-	ProcessedTracks    int                      `dynamodbav:"processedTracks"`
-	MatchedTracks      int                      `dynamodbav:"matchedTracks"`
-	FailedTracks       int                      `dynamodbav:"failedTracks"`
-	ErrorMessage       string                   `dynamodbav:"errorMessage,omitempty"`
-	CreatedAt          string                   `dynamodbav:"createdAt"`
-	UpdatedAt          string                   `dynamodbav:"updatedAt"`
-	CompletedAt        string                   `dynamodbav:"completedAt,omitempty"`
+	ID                 string                  `dynamodbav:"id"`
+	UserID             string                  `dynamodbav:"userId"`
+	SourcePlatform     domain.Platform         `dynamodbav:"sourcePlatform"`
+	TargetPlatform     domain.Platform         `dynamodbav:"targetPlatform"`
+	SourcePlaylistID   string                  `dynamodbav:"sourcePlaylistId"`
+	SourcePlaylistName string                  `dynamodbav:"sourcePlaylistName,omitempty"`
+	TargetPlaylistID   string                  `dynamodbav:"targetPlaylistId,omitempty"`
+	TargetPlaylistURL  string                  `dynamodbav:"targetPlaylistUrl,omitempty"`
+	TargetPlaylistName string                  `dynamodbav:"targetPlaylistName,omitempty"`
+	Status             domain.ConversionStatus `dynamodbav:"status"`
+	TotalTracks        int                     `dynamodbav:"totalTracks"`
+	ProcessedTracks    int                     `dynamodbav:"processedTracks"`
+	MatchedTracks      int                     `dynamodbav:"matchedTracks"`
+	ReviewTracks       int                     `dynamodbav:"reviewTracks"`
+	FailedTracks       int                     `dynamodbav:"failedTracks"`
+	FailedInserts      int                     `dynamodbav:"failedInserts"`
+	InsertedVideoIDs   []string                `dynamodbav:"insertedVideoIds,omitempty"`
+	ErrorMessage       string                  `dynamodbav:"errorMessage,omitempty"`
+	Experiment         string                  `dynamodbav:"experiment,omitempty"`
+	Variant            string                  `dynamodbav:"variant,omitempty"`
+	CreatedAt          string                  `dynamodbav:"createdAt"`
+	UpdatedAt          string                  `dynamodbav:"updatedAt"`
+	CompletedAt        string                  `dynamodbav:"completedAt,omitempty"`
+	LeaseUntil         string                  `dynamodbav:"leaseUntil,omitempty"`
+	Version            int                     `dynamodbav:"version"`
 }
 
 type conversionRepository struct {
@@ -45,25 +56,84 @@ func NewConversionRepository(cfg aws.Config, tableName string) application.Conve
 	}
 }
 
+// Create claims the job: the write only succeeds when no conversion with the same ID exists.
 func (r *conversionRepository) Create(ctx context.Context, c *domain.Conversion) error {
+	err := r.put(ctx, c, 1, "attribute_not_exists(id)", nil, nil)
+	var conditionErr *types.ConditionalCheckFailedException
+	if errors.As(err, &conditionErr) {
+		return fmt.Errorf("%w: %s", application.ErrConversionExists, c.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create conversion: %w", err)
+	}
+	c.Version = 1
+	return nil
+}
+
+// Update only overwrites the version that was read, so a worker that lost the conversion
+// to another one cannot write over its progress.
+func (r *conversionRepository) Update(ctx context.Context, c *domain.Conversion) error {
+	// Conversions stored before versioning have no version attribute yet.
+	condition := "attribute_exists(id) AND attribute_not_exists(#version)"
+	var values map[string]types.AttributeValue
+	if c.Version > 0 {
+		condition = "#version = :version"
+		values = map[string]types.AttributeValue{
+			":version": &types.AttributeValueMemberN{Value: strconv.Itoa(c.Version)},
+		}
+	}
+
+	names := map[string]string{"#version": "version"}
+	err := r.put(ctx, c, c.Version+1, condition, names, values)
+	var conditionErr *types.ConditionalCheckFailedException
+	if errors.As(err, &conditionErr) {
+		return fmt.Errorf("%w: %s", application.ErrConversionClaimed, c.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update conversion: %w", err)
+	}
+	c.Version++
+	return nil
+}
+
+func (r *conversionRepository) put(ctx context.Context, c *domain.Conversion, version int, condition string, names map[string]string, values map[string]types.AttributeValue) error {
 	item := toConversionItem(c)
+	item.Version = version
 	av, err := attributevalue.MarshalMap(item)
 	if err != nil {
 		return fmt.Errorf("failed to marshal conversion: %w", err)
 	}
 
 	_, err = r.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:                 &r.tableName,
+		Item:                      av,
+		ConditionExpression:       aws.String(condition),
+		ExpressionAttributeNames:  names,
+		ExpressionAttributeValues: values,
+	})
+	return err
+}
+
+func (r *conversionRepository) Get(ctx context.Context, id string) (*domain.Conversion, error) {
+	out, err := r.client.GetItem(ctx, &dynamodb.GetItemInput{
 		TableName: &r.tableName,
-		Item:      av,
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: id},
+		},
+		ConsistentRead: aws.Bool(true),
 	})
 	if err != nil {
-		return fmt.Errorf("failed to create conversion: %w", err)
+		return nil, fmt.Errorf("failed to get conversion: %w", err)
+	}
+	if out.Item == nil {
+		return nil, nil
 	}
-	return nil
-}
 
-func (r *conversionRepository) Update(ctx context.Context, c *domain.Conversion) error {
-	return r.Create(ctx, c)
+	var item conversionItem
+	if err := attributevalue.UnmarshalMap(out.Item, &item); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal conversion: %w", err)
+	}
+	return fromConversionItem(item), nil
 }
 
 func toConversionItem(c *domain.Conversion) conversionItem {
@@ -81,13 +151,64 @@ func toConversionItem(c *domain.Conversion) conversionItem {
 		TotalTracks:        c.TotalTracks,
 		ProcessedTracks:    c.ProcessedTracks,
 		MatchedTracks:      c.MatchedTracks,
+		ReviewTracks:       c.ReviewTracks,
 		FailedTracks:       c.FailedTracks,
+		FailedInserts:      c.FailedInserts,
+		InsertedVideoIDs:   c.InsertedVideoIDs,
 		ErrorMessage:       c.ErrorMessage,
+		Experiment:         c.Experiment,
+		Variant:            c.Variant,
 		CreatedAt:          c.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt:          c.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		Version:            c.Version,
 	}
 	if c.CompletedAt != nil {
 		item.CompletedAt = c.CompletedAt.Format("2006-01-02T15:04:05Z07:00")
 	}
+	if c.LeaseUntil != nil {
+		item.LeaseUntil = c.LeaseUntil.Format("2006-01-02T15:04:05Z07:00")
+	}
 	return item
 }
+
+func fromConversionItem(item conversionItem) *domain.Conversion {
+	c := &domain.Conversion{
+		ID:                 item.ID,
+		UserID:             item.UserID,
+		SourcePlatform:     item.SourcePlatform,
+		TargetPlatform:     item.TargetPlatform,
+		SourcePlaylistID:   item.SourcePlaylistID,
+		SourcePlaylistName: item.SourcePlaylistName,
+		TargetPlaylistID:   item.TargetPlaylistID,
+		TargetPlaylistURL:  item.TargetPlaylistURL,
+		TargetPlaylistName: item.TargetPlaylistName,
+		Status:             item.Status,
+		TotalTracks:        item.TotalTracks,
+		ProcessedTracks:    item.ProcessedTracks,
+		MatchedTracks:      item.MatchedTracks,
+		ReviewTracks:       item.ReviewTracks,
+		FailedTracks:       item.FailedTracks,
+		FailedInserts:      item.FailedInserts,
+		InsertedVideoIDs:   item.InsertedVideoIDs,
+		ErrorMessage:       item.ErrorMessage,
+		Experiment:         item.Experiment,
+		Variant:            item.Variant,
+		CreatedAt:          parseTime(item.CreatedAt),
+		UpdatedAt:          parseTime(item.UpdatedAt),
+		Version:            item.Version,
+	}
+	if item.CompletedAt != "" {
+		completedAt := parseTime(item.CompletedAt)
+		c.CompletedAt = &completedAt
+	}
+	if item.LeaseUntil != "" {
+		leaseUntil := parseTime(item.LeaseUntil)
+		c.LeaseUntil = &leaseUntil
+	}
+	return c
+}
+
+func parseTime(value string) time.Time {
+	t, _ := time.Parse("2006-01-02T15:04:05Z07:00", value)
+	return t
+}
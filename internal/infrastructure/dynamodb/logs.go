@@ -17,18 +17,34 @@ import (
 const logTTLDays = 30
 
 type logItem struct {
-	ID                string               `dynamodbav:"id"`
-	ConversionID      string               `dynamodbav:"conversionId"`
-	Step              domain.ConversionStep `dynamodbav:"step"`
-	Status            domain.LogStatus      `dynamodbav:"status"`
-	SourceTrackID     string               `dynamodbav:"sourceTrackId,omitempty"`
-	SourceTrackName   string               `dynamodbav:"sourceTrackName,omitempty"`
-	SourceTrackArtist string               `dynamodbav:"sourceTrackArtist,omitempty"`
-	TargetTrackID     string               `dynamodbav:"targetTrackId,omitempty"`
-	TargetTrackName   string               `dynamodbav:"targetTrackName,omitempty"`
-	ErrorMessage      string               `dynamodbav:"errorMessage,omitempty"`
-	CreatedAt         string               `dynamodbav:"createdAt"`
-	TTL               int64                `dynamodbav:"ttl"`
+	ID                string                 `dynamodbav:"id"`
+	ConversionID      string                 `dynamodbav:"conversionId"`
+	Step              domain.ConversionStep  `dynamodbav:"step"`
+	Status            domain.LogStatus       `dynamodbav:"status"`
+	SourceTrackID     string                 `dynamodbav:"sourceTrackId,omitempty"`
+	SourceTrackName   string                 `dynamodbav:"sourceTrackName,omitempty"`
+	SourceTrackArtist string                 `dynamodbav:"sourceTrackArtist,omitempty"`
+	TargetTrackID     string                 `dynamodbav:"targetTrackId,omitempty"`
+	TargetTrackName   string                 `dynamodbav:"targetTrackName,omitempty"`
+	PreviousTargetID  string                 `dynamodbav:"previousTargetId,omitempty"`
+	DuplicateOfID     string                 `dynamodbav:"duplicateOfId,omitempty"`
+	MatchMethod       string                 `dynamodbav:"matchMethod,omitempty"`
+	Confidence        domain.MatchConfidence `dynamodbav:"confidence,omitempty"`
+	ScoreBreakdown    map[string]float64     `dynamodbav:"scoreBreakdown,omitempty"`
+	Rejected          []rejectedItem         `dynamodbav:"rejected,omitempty"`
+	Variant           string                 `dynamodbav:"variant,omitempty"`
+	ErrorMessage      string                 `dynamodbav:"errorMessage,omitempty"`
+	CreatedAt         string                 `dynamodbav:"createdAt"`
+	TTL               int64                  `dynamodbav:"ttl"`
+}
+
+type rejectedItem struct {
+	PlatformID string             `dynamodbav:"platformId"`
+	Name       string             `dynamodbav:"name"`
+	Artist     string             `dynamodbav:"artist"`
+	Score      float64            `dynamodbav:"score"`
+	Breakdown  map[string]float64 `dynamodbav:"breakdown,omitempty"`
+	Reason     string             `dynamodbav:"reason"`
 }
 
 type conversionLogRepository struct {
@@ -36,6 +52,13 @@ type conversionLogRepository struct {
 	tableName string
 }
 
+func NewConversionLogReader(cfg aws.Config, tableName string) application.ConversionLogReader {
+	return &conversionLogRepository{
+		client:    dynamodb.NewFromConfig(cfg),
+		tableName: tableName,
+	}
+}
+
 func NewConversionLogRepository(cfg aws.Config, tableName string) application.ConversionLogRepository {
 	return &conversionLogRepository{
 		client:    dynamodb.NewFromConfig(cfg),
@@ -65,39 +88,58 @@ func (r *conversionLogRepository) CreateBatch(ctx context.Context, logs []*domai
 		return nil
 	}
 
-	for i := 0; i < len(logs); i += 25 {
-		end := i + 25
-		if end > len(logs) {
-			end = len(logs)
+	requests := make([]types.WriteRequest, 0, len(logs))
+	for _, l := range logs {
+		av, err := attributevalue.MarshalMap(toLogItem(l))
+		if err != nil {
+			return fmt.Errorf("failed to marshal log: %w", err)
 		}
+		requests = append(requests, types.WriteRequest{
+			PutRequest: &types.PutRequest{Item: av},
+		})
+	}
 
-		var requests []types.WriteRequest
-		for _, l := range logs[i:end] {
-			item := toLogItem(l)
-			av, err := attributevalue.MarshalMap(item)
-			if err != nil {
-				return fmt.Errorf("failed to marshal log: %w", err)
-			}
-			requests = append(requests, types.WriteRequest{
-				PutRequest: &types.PutRequest{Item: av},
-			})
-		}
+	if err := batchWrite(ctx, r.client, r.tableName, requests); err != nil {
+		return fmt.Errorf("failed to batch write logs: %w", err)
+	}
 
-		_, err := r.client.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{
-			RequestItems: map[string][]types.WriteRequest{
-				r.tableName: requests,
-			},
-		})
+	return nil
+}
+
+func (r *conversionLogRepository) ListForTraining(ctx context.Context) ([]*domain.ConversionLog, error) {
+	paginator := dynamodb.NewScanPaginator(r.client, &dynamodb.ScanInput{
+		TableName:                &r.tableName,
+		FilterExpression:         aws.String("#step IN (:match, :review, :swap)"),
+		ExpressionAttributeNames: map[string]string{"#step": "step"},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":match":  &types.AttributeValueMemberS{Value: string(domain.StepMatchTrack)},
+			":review": &types.AttributeValueMemberS{Value: string(domain.StepReviewTrack)},
+			":swap":   &types.AttributeValueMemberS{Value: string(domain.StepSwapTrack)},
+		},
+	})
+
+	var logs []*domain.ConversionLog
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
 		if err != nil {
-			return fmt.Errorf("failed to batch write logs: %w", err)
+			return nil, fmt.Errorf("failed to scan logs: %w", err)
+		}
+
+		var items []logItem
+		if err := attributevalue.UnmarshalListOfMaps(page.Items, &items); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal logs: %w", err)
+		}
+
+		for _, item := range items {
+			logs = append(logs, fromLogItem(item))
 		}
 	}
 
-	return nil
+	return logs, nil
 }
 
 func toLogItem(l *domain.ConversionLog) logItem {
-	return logItem{
+	item := logItem{
 		ID:                l.ID,
 		ConversionID:      l.ConversionID,
 		Step:              l.Step,
@@ -107,8 +149,59 @@ func toLogItem(l *domain.ConversionLog) logItem {
 		SourceTrackArtist: l.SourceTrackArtist,
 		TargetTrackID:     l.TargetTrackID,
 		TargetTrackName:   l.TargetTrackName,
+		PreviousTargetID:  l.PreviousTargetID,
+		DuplicateOfID:     l.DuplicateOfID,
+		MatchMethod:       l.MatchMethod,
+		Confidence:        l.Confidence,
+		ScoreBreakdown:    l.ScoreBreakdown,
+		Variant:           l.Variant,
 		ErrorMessage:      l.ErrorMessage,
 		CreatedAt:         l.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		TTL:               time.Now().Add(logTTLDays * 24 * time.Hour).Unix(),
 	}
+	for _, rejected := range l.Rejected {
+		item.Rejected = append(item.Rejected, rejectedItem{
+			PlatformID: rejected.PlatformID,
+			Name:       rejected.Name,
+			Artist:     rejected.Artist,
+			Score:      rejected.Score,
+			Breakdown:  rejected.Breakdown,
+			Reason:     rejected.Reason,
+		})
+	}
+	return item
+}
+
+func fromLogItem(item logItem) *domain.ConversionLog {
+	createdAt, _ := time.Parse(time.RFC3339, item.CreatedAt)
+	l := &domain.ConversionLog{
+		ID:                item.ID,
+		ConversionID:      item.ConversionID,
+		Step:              item.Step,
+		Status:            item.Status,
+		SourceTrackID:     item.SourceTrackID,
+		SourceTrackName:   item.SourceTrackName,
+		SourceTrackArtist: item.SourceTrackArtist,
+		TargetTrackID:     item.TargetTrackID,
+		TargetTrackName:   item.TargetTrackName,
+		PreviousTargetID:  item.PreviousTargetID,
+		DuplicateOfID:     item.DuplicateOfID,
+		MatchMethod:       item.MatchMethod,
+		Confidence:        item.Confidence,
+		ScoreBreakdown:    item.ScoreBreakdown,
+		Variant:           item.Variant,
+		ErrorMessage:      item.ErrorMessage,
+		CreatedAt:         createdAt,
+	}
+	for _, rejected := range item.Rejected {
+		l.Rejected = append(l.Rejected, domain.RejectedCandidate{
+			PlatformID: rejected.PlatformID,
+			Name:       rejected.Name,
+			Artist:     rejected.Artist,
+			Score:      rejected.Score,
+			Breakdown:  rejected.Breakdown,
+			Reason:     rejected.Reason,
+		})
+	}
+	return l
 }
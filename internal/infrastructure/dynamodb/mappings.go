@@ -0,0 +1,141 @@
+package dynamodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/application"
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/domain"
+)
+
+type mappingItem struct {
+	SourceKey          string               `dynamodbav:"sourceKey"`
+	TargetID           string               `dynamodbav:"targetId"`
+	SourcePlatform     domain.Platform      `dynamodbav:"sourcePlatform"`
+	SourcePlatformID   string               `dynamodbav:"sourcePlatformId"`
+	TargetPlatform     domain.Platform      `dynamodbav:"targetPlatform"`
+	TargetName         string               `dynamodbav:"targetName"`
+	TargetArtist       string               `dynamodbav:"targetArtist"`
+	TargetChannelID    string               `dynamodbav:"targetChannelId,omitempty"`
+	TargetExplicit     bool                 `dynamodbav:"targetExplicit"`
+	TargetAvailability *domain.Availability `dynamodbav:"targetAvailability,omitempty"`
+	MatchVotes         int                  `dynamodbav:"matchVotes"`
+	CorrectionVotes    int                  `dynamodbav:"correctionVotes"`
+	UpdatedAt          string               `dynamodbav:"updatedAt"`
+}
+
+type trackMappingRepository struct {
+	client    *dynamodb.Client
+	tableName string
+}
+
+func NewTrackMappingRepository(cfg aws.Config, tableName string) application.TrackMappingRepository {
+	return &trackMappingRepository{
+		client:    dynamodb.NewFromConfig(cfg),
+		tableName: tableName,
+	}
+}
+
+func mappingSourceKey(sourcePlatform domain.Platform, sourceID string, targetPlatform domain.Platform) string {
+	return fmt.Sprintf("%s#%s#%s", sourcePlatform, sourceID, targetPlatform)
+}
+
+func (r *trackMappingRepository) FindBest(ctx context.Context, sourcePlatform domain.Platform, sourceID string, targetPlatform domain.Platform) (*domain.TrackMapping, error) {
+	out, err := r.client.Query(ctx, &dynamodb.QueryInput{
+		TableName:              &r.tableName,
+		KeyConditionExpression: aws.String("sourceKey = :sourceKey"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":sourceKey": &types.AttributeValueMemberS{Value: mappingSourceKey(sourcePlatform, sourceID, targetPlatform)},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query track mappings: %w", err)
+	}
+
+	var items []mappingItem
+	if err := attributevalue.UnmarshalListOfMaps(out.Items, &items); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal track mappings: %w", err)
+	}
+
+	mappings := make([]*domain.TrackMapping, 0, len(items))
+	for _, item := range items {
+		mappings = append(mappings, fromMappingItem(item))
+	}
+
+	return domain.BestMapping(mappings), nil
+}
+
+func (r *trackMappingRepository) RecordVote(ctx context.Context, mapping *domain.TrackMapping, vote domain.MappingVote) error {
+	counter := "matchVotes"
+	if vote == domain.MappingVoteCorrection {
+		counter = "correctionVotes"
+	}
+
+	values := map[string]types.AttributeValue{
+		":one":              &types.AttributeValueMemberN{Value: "1"},
+		":sourcePlatform":   &types.AttributeValueMemberS{Value: mapping.SourcePlatform.String()},
+		":sourcePlatformId": &types.AttributeValueMemberS{Value: mapping.SourcePlatformID},
+		":targetPlatform":   &types.AttributeValueMemberS{Value: mapping.TargetPlatform.String()},
+		":targetName":       &types.AttributeValueMemberS{Value: mapping.TargetName},
+		":targetArtist":     &types.AttributeValueMemberS{Value: mapping.TargetArtist},
+		":targetChannelId":  &types.AttributeValueMemberS{Value: mapping.TargetChannelID},
+		":targetExplicit":   &types.AttributeValueMemberBOOL{Value: mapping.TargetExplicit},
+		":updatedAt":        &types.AttributeValueMemberS{Value: time.Now().Format("2006-01-02T15:04:05Z07:00")},
+	}
+
+	update := "ADD #counter :one SET sourcePlatform = :sourcePlatform, sourcePlatformId = :sourcePlatformId, " +
+		"targetPlatform = :targetPlatform, targetName = :targetName, targetArtist = :targetArtist, targetChannelId = :targetChannelId, " +
+		"targetExplicit = :targetExplicit, updatedAt = :updatedAt"
+
+	// Every vote refreshes the stored availability, so a video that became unavailable stops being reused.
+	if mapping.TargetAvailability != nil {
+		availability, err := attributevalue.Marshal(mapping.TargetAvailability)
+		if err != nil {
+			return fmt.Errorf("failed to marshal track mapping availability: %w", err)
+		}
+		values[":targetAvailability"] = availability
+		update += ", targetAvailability = :targetAvailability"
+	} else {
+		update += " REMOVE targetAvailability"
+	}
+
+	_, err := r.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: &r.tableName,
+		Key: map[string]types.AttributeValue{
+			"sourceKey": &types.AttributeValueMemberS{Value: mappingSourceKey(mapping.SourcePlatform, mapping.SourcePlatformID, mapping.TargetPlatform)},
+			"targetId":  &types.AttributeValueMemberS{Value: mapping.TargetPlatformID},
+		},
+		UpdateExpression: aws.String(update),
+		ExpressionAttributeNames: map[string]string{
+			"#counter": counter,
+		},
+		ExpressionAttributeValues: values,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record track mapping vote: %w", err)
+	}
+	return nil
+}
+
+func fromMappingItem(item mappingItem) *domain.TrackMapping {
+	return &domain.TrackMapping{
+		SourcePlatform:     item.SourcePlatform,
+		SourcePlatformID:   item.SourcePlatformID,
+		TargetPlatform:     item.TargetPlatform,
+		TargetPlatformID:   item.TargetID,
+		TargetName:         item.TargetName,
+		TargetArtist:       item.TargetArtist,
+		TargetChannelID:    item.TargetChannelID,
+		TargetExplicit:     item.TargetExplicit,
+		TargetAvailability: item.TargetAvailability,
+		MatchVotes:         item.MatchVotes,
+		CorrectionVotes:    item.CorrectionVotes,
+		UpdatedAt:          parseTime(item.UpdatedAt),
+	}
+}
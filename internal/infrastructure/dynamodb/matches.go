@@ -0,0 +1,184 @@
+package dynamodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/application"
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/domain"
+)
+
+const matchTTLDays = 30
+
+type trackItem struct {
+	ID         string          `dynamodbav:"id"`
+	Name       string          `dynamodbav:"name"`
+	Artist     string          `dynamodbav:"artist"`
+	ChannelID  string          `dynamodbav:"channelId,omitempty"`
+	Album      string          `dynamodbav:"album,omitempty"`
+	DurationMs int             `dynamodbav:"durationMs,omitempty"`
+	ISRC       string          `dynamodbav:"isrc,omitempty"`
+	Artists    []string        `dynamodbav:"artists,omitempty"`
+	Explicit   bool            `dynamodbav:"explicit,omitempty"`
+	Kind       string          `dynamodbav:"kind,omitempty"`
+	Official   bool            `dynamodbav:"officialChannel,omitempty"`
+	Platform   domain.Platform `dynamodbav:"platform"`
+	PlatformID string          `dynamodbav:"platformId"`
+}
+
+type matchItem struct {
+	ConversionID string                 `dynamodbav:"conversionId"`
+	Position     int                    `dynamodbav:"position"`
+	SourceTrack  trackItem              `dynamodbav:"sourceTrack"`
+	TargetTrack  *trackItem             `dynamodbav:"targetTrack,omitempty"`
+	Confidence   domain.MatchConfidence `dynamodbav:"confidence"`
+	MatchMethod  string                 `dynamodbav:"matchMethod,omitempty"`
+	NeedsReview  bool                   `dynamodbav:"needsReview"`
+	Candidates   []trackItem            `dynamodbav:"candidates,omitempty"`
+	Error        string                 `dynamodbav:"error,omitempty"`
+	TTL          int64                  `dynamodbav:"ttl"`
+}
+
+type matchRepository struct {
+	client    *dynamodb.Client
+	tableName string
+}
+
+func NewMatchRepository(cfg aws.Config, tableName string) application.MatchRepository {
+	return &matchRepository{
+		client:    dynamodb.NewFromConfig(cfg),
+		tableName: tableName,
+	}
+}
+
+func (r *matchRepository) SaveBatch(ctx context.Context, conversionID string, matches []*domain.TrackMatch) error {
+	if len(matches) == 0 {
+		return nil
+	}
+
+	requests := make([]types.WriteRequest, 0, len(matches))
+	for position, match := range matches {
+		av, err := attributevalue.MarshalMap(toMatchItem(conversionID, position, match))
+		if err != nil {
+			return fmt.Errorf("failed to marshal match: %w", err)
+		}
+		requests = append(requests, types.WriteRequest{
+			PutRequest: &types.PutRequest{Item: av},
+		})
+	}
+
+	if err := batchWrite(ctx, r.client, r.tableName, requests); err != nil {
+		return fmt.Errorf("failed to batch write matches: %w", err)
+	}
+
+	return nil
+}
+
+func (r *matchRepository) ListByConversion(ctx context.Context, conversionID string) ([]*domain.TrackMatch, error) {
+	paginator := dynamodb.NewQueryPaginator(r.client, &dynamodb.QueryInput{
+		TableName:              &r.tableName,
+		KeyConditionExpression: aws.String("conversionId = :conversionId"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":conversionId": &types.AttributeValueMemberS{Value: conversionID},
+		},
+	})
+
+	var matches []*domain.TrackMatch
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query matches: %w", err)
+		}
+
+		var items []matchItem
+		if err := attributevalue.UnmarshalListOfMaps(page.Items, &items); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal matches: %w", err)
+		}
+
+		for _, item := range items {
+			matches = append(matches, fromMatchItem(item))
+		}
+	}
+
+	return matches, nil
+}
+
+func toMatchItem(conversionID string, position int, m *domain.TrackMatch) matchItem {
+	item := matchItem{
+		ConversionID: conversionID,
+		Position:     position,
+		SourceTrack:  toTrackItem(m.SourceTrack),
+		Confidence:   m.Confidence,
+		MatchMethod:  m.MatchMethod,
+		NeedsReview:  m.NeedsReview,
+		Error:        m.Error,
+		TTL:          time.Now().Add(matchTTLDays * 24 * time.Hour).Unix(),
+	}
+	if m.TargetTrack != nil {
+		target := toTrackItem(m.TargetTrack)
+		item.TargetTrack = &target
+	}
+	for _, candidate := range m.Candidates {
+		item.Candidates = append(item.Candidates, toTrackItem(candidate))
+	}
+	return item
+}
+
+func fromMatchItem(item matchItem) *domain.TrackMatch {
+	match := &domain.TrackMatch{
+		SourceTrack: fromTrackItem(item.SourceTrack),
+		Confidence:  item.Confidence,
+		MatchMethod: item.MatchMethod,
+		NeedsReview: item.NeedsReview,
+		Error:       item.Error,
+	}
+	if item.TargetTrack != nil {
+		match.TargetTrack = fromTrackItem(*item.TargetTrack)
+	}
+	for _, candidate := range item.Candidates {
+		match.Candidates = append(match.Candidates, fromTrackItem(candidate))
+	}
+	return match
+}
+
+func toTrackItem(t *domain.Track) trackItem {
+	return trackItem{
+		ID:         t.ID,
+		Name:       t.Name,
+		Artist:     t.Artist,
+		ChannelID:  t.ChannelID,
+		Album:      t.Album,
+		DurationMs: t.DurationMs,
+		ISRC:       t.ISRC,
+		Artists:    t.Artists,
+		Explicit:   t.Explicit,
+		Kind:       string(t.Kind),
+		Official:   t.OfficialChannel,
+		Platform:   t.Platform,
+		PlatformID: t.PlatformID,
+	}
+}
+
+func fromTrackItem(item trackItem) *domain.Track {
+	return &domain.Track{
+		ID:              item.ID,
+		Name:            item.Name,
+		Artist:          item.Artist,
+		ChannelID:       item.ChannelID,
+		Album:           item.Album,
+		DurationMs:      item.DurationMs,
+		ISRC:            item.ISRC,
+		Artists:         item.Artists,
+		Explicit:        item.Explicit,
+		Kind:            domain.ResultKind(item.Kind),
+		OfficialChannel: item.Official,
+		Platform:        item.Platform,
+		PlatformID:      item.PlatformID,
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"io"
 	"log"
 	"net/http"
+	"strings"
 
 	"github.com/marcelovmendes/playswap/conversion-worker/internal/config"
 	"github.com/marcelovmendes/playswap/conversion-worker/internal/domain"
@@ -14,7 +15,7 @@ import (
 )
 
 type SpotifyClient interface {
-	GetPlaylistTracks(ctx context.Context, playlistID, sessionID string) (*domain.Playlist, error)
+	GetPlaylistTracks(ctx context.Context, playlistID, market, sessionID string) (*domain.Playlist, error)
 }
 
 type spotifyClient struct {
@@ -41,14 +42,18 @@ type spotifyPlaylistResponse struct {
 }
 
 type spotifyTrack struct {
-	ID         string `json:"id"`
-	Name       string `json:"name"`
-	Artist     string `json:"artist"`
-	Album      string `json:"album"`
-	DurationMs int    `json:"durationMs"`
+	ID           string   `json:"id"`
+	Name         string   `json:"name"`
+	Artist       string   `json:"artist"`
+	Album        string   `json:"album"`
+	DurationMs   int      `json:"durationMs"`
+	ISRC         string   `json:"isrc"`
+	Explicit     bool     `json:"explicit"`
+	LinkedFromID string   `json:"linkedFromId"`
+	Artists      []string `json:"artists"`
 }
 
-func (c *spotifyClient) GetPlaylistTracks(ctx context.Context, playlistID, sessionID string) (*domain.Playlist, error) {
+func (c *spotifyClient) GetPlaylistTracks(ctx context.Context, playlistID, market, sessionID string) (*domain.Playlist, error) {
 	log.Printf("[DEBUG] fetching Spotify token for session: %s", sessionID)
 
 	token, err := c.sessionStore.GetSpotifyToken(ctx, sessionID)
@@ -66,6 +71,9 @@ func (c *spotifyClient) GetPlaylistTracks(ctx context.Context, playlistID, sessi
 	for {
 		url := fmt.Sprintf("%s/internal/playlists/%s/tracks?limit=%d&offset=%d",
 			c.baseURL, playlistID, limit, offset)
+		if market != "" {
+			url += "&market=" + market
+		}
 
 		log.Printf("[DEBUG] Spotify request URL: %s", url)
 
@@ -130,12 +138,18 @@ func toTrack(st spotifyTrack) *domain.Track {
 		return nil
 	}
 
-	track, err := domain.NewTrack(st.Name, st.Artist, domain.PlatformSpotify, st.ID)
+	// Relinked tracks keep the ID stored in the playlist so selections and mappings stay stable.
+	id := st.ID
+	if st.LinkedFromID != "" {
+		id = st.LinkedFromID
+	}
+
+	track, err := domain.NewTrack(st.Name, st.Artist, domain.PlatformSpotify, id)
 	if err != nil {
 		return nil
 	}
 
-	track.WithAlbum(st.Album).WithDuration(st.DurationMs)
+	track.WithAlbum(st.Album).WithDuration(st.DurationMs).WithISRC(strings.ToUpper(strings.TrimSpace(st.ISRC))).WithExplicit(st.Explicit).WithArtists(st.Artists)
 
 	return track
 }
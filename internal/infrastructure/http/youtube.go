@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -17,10 +18,11 @@ import (
 )
 
 type YouTubeClient interface {
-	SearchByISRC(ctx context.Context, isrc, sessionID string) (*domain.Track, error)
-	SearchTrack(ctx context.Context, track, artist, sessionID string) ([]*domain.Track, error)
+	SearchByISRC(ctx context.Context, isrc, market, sessionID string) (*domain.Track, error)
+	SearchTrack(ctx context.Context, track, artist, market, sessionID string) ([]*domain.Track, error)
 	CreatePlaylist(ctx context.Context, name, description, sessionID string) (playlistID string, playlistURL string, err error)
-	AddVideosToPlaylist(ctx context.Context, playlistID string, videoIDs []string, sessionID string) error
+	AddVideosToPlaylist(ctx context.Context, playlistID string, videoIDs []string, sessionID string) ([]domain.VideoInsertResult, error)
+	ReplaceVideoInPlaylist(ctx context.Context, playlistID, oldVideoID, newVideoID, sessionID string) error
 }
 
 type youtubeClient struct {
@@ -38,10 +40,29 @@ type createPlaylistResponse struct {
 	URL string `json:"url"`
 }
 
+const (
+	addVideosChunkSize   = 50
+	addVideoStatusFailed = "FAILED"
+)
+
 type addVideosRequest struct {
 	VideoIDs []string `json:"videoIds"`
 }
 
+type addVideosResponse struct {
+	Results []addVideoResultItem `json:"results"`
+}
+
+type addVideoResultItem struct {
+	VideoID string `json:"videoId"`
+	Status  string `json:"status"`
+	Error   string `json:"error,omitempty"`
+}
+
+type replaceVideoRequest struct {
+	VideoID string `json:"videoId"`
+}
+
 func NewYouTubeClient(cfg config.ServiceConfig, sessionStore redis.SessionStore) YouTubeClient {
 	return &youtubeClient{
 		baseURL: cfg.BaseURL,
@@ -70,15 +91,27 @@ func sanitizeToken(token string) string {
 }
 
 type youtubeSearchResponse struct {
-	VideoID        string  `json:"videoId"`
-	Title          string  `json:"title"`
-	ChannelTitle   string  `json:"channelTitle"`
-	Description    string  `json:"description"`
-	ThumbnailURL   string  `json:"thumbnailUrl"`
-	RelevanceScore float64 `json:"relevanceScore"`
+	VideoID        string   `json:"videoId"`
+	Title          string   `json:"title"`
+	ChannelID      string   `json:"channelId"`
+	ChannelTitle   string   `json:"channelTitle"`
+	Description    string   `json:"description"`
+	ThumbnailURL   string   `json:"thumbnailUrl"`
+	RelevanceScore float64  `json:"relevanceScore"`
+	Embeddable     *bool    `json:"embeddable"`
+	AllowedRegions []string `json:"allowedRegions"`
+	BlockedRegions []string `json:"blockedRegions"`
+	ResultType     string   `json:"resultType"`
+	ChannelBadges  []string `json:"channelBadges"`
+}
+
+// youtubeMusicSearchResponse is the body of the music search endpoint: every candidate video
+// in the service's ranking order, with an empty list when nothing was found.
+type youtubeMusicSearchResponse struct {
+	Results []youtubeSearchResponse `json:"results"`
 }
 
-func (c *youtubeClient) SearchByISRC(ctx context.Context, isrc, sessionID string) (*domain.Track, error) {
+func (c *youtubeClient) SearchByISRC(ctx context.Context, isrc, market, sessionID string) (*domain.Track, error) {
 	if isrc == "" {
 		return nil, nil
 	}
@@ -88,7 +121,7 @@ func (c *youtubeClient) SearchByISRC(ctx context.Context, isrc, sessionID string
 		return nil, err
 	}
 
-	searchURL := fmt.Sprintf("%s/v1/search/track?isrc=%s", c.baseURL, url.QueryEscape(isrc))
+	searchURL := fmt.Sprintf("%s/v1/search/track?isrc=%s", c.baseURL, url.QueryEscape(isrc)) + regionParam(market)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, searchURL, nil)
 	if err != nil {
@@ -125,14 +158,14 @@ func (c *youtubeClient) SearchByISRC(ctx context.Context, isrc, sessionID string
 	return c.responseToTrack(result)
 }
 
-func (c *youtubeClient) SearchTrack(ctx context.Context, track, artist, sessionID string) ([]*domain.Track, error) {
+func (c *youtubeClient) SearchTrack(ctx context.Context, track, artist, market, sessionID string) ([]*domain.Track, error) {
 	authHeader, err := c.getAuthHeader(ctx, sessionID)
 	if err != nil {
 		return nil, err
 	}
 
 	searchURL := fmt.Sprintf("%s/v1/search/music?track=%s&artist=%s",
-		c.baseURL, url.QueryEscape(track), url.QueryEscape(artist))
+		c.baseURL, url.QueryEscape(track), url.QueryEscape(artist)) + regionParam(market)
 
 	log.Printf("[DEBUG] YouTube search URL: %s", searchURL)
 
@@ -157,21 +190,24 @@ func (c *youtubeClient) SearchTrack(ctx context.Context, track, artist, sessionI
 		return nil, fmt.Errorf("youtube service returned status %d: %s", resp.StatusCode, string(body))
 	}
 
-	var result youtubeSearchResponse
+	var result youtubeMusicSearchResponse
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
-	if result.VideoID == "" {
-		return nil, nil
-	}
-
-	domainTrack, err := c.responseToTrack(result)
-	if err != nil {
-		return nil, err
+	var tracks []*domain.Track
+	for _, item := range result.Results {
+		if item.VideoID == "" {
+			continue
+		}
+		domainTrack, err := c.responseToTrack(item)
+		if err != nil {
+			return nil, err
+		}
+		tracks = append(tracks, domainTrack)
 	}
 
-	return []*domain.Track{domainTrack}, nil
+	return tracks, nil
 }
 
 func (c *youtubeClient) responseToTrack(resp youtubeSearchResponse) (*domain.Track, error) {
@@ -179,9 +215,65 @@ func (c *youtubeClient) responseToTrack(resp youtubeSearchResponse) (*domain.Tra
 	if err != nil {
 		return nil, fmt.Errorf("failed to create track: %w", err)
 	}
+
+	if resp.Embeddable != nil || len(resp.AllowedRegions) > 0 || len(resp.BlockedRegions) > 0 {
+		track.WithAvailability(&domain.Availability{
+			Embeddable:     resp.Embeddable == nil || *resp.Embeddable,
+			AllowedRegions: resp.AllowedRegions,
+			BlockedRegions: resp.BlockedRegions,
+		})
+	}
+
+	kind, official := classifyResult(resp)
+	track.WithChannelID(resp.ChannelID).WithKind(kind).WithOfficialChannel(official)
+
 	return track, nil
 }
 
+// classifyResult uses the service's result type and channel badges when present, and otherwise
+// recognizes auto-generated "Artist - Topic" channels and VEVO channels by name.
+func classifyResult(resp youtubeSearchResponse) (domain.ResultKind, bool) {
+	kind := domain.ResultKindUnknown
+	switch strings.ToLower(resp.ResultType) {
+	case "song":
+		kind = domain.ResultKindSong
+	case "video":
+		kind = domain.ResultKindVideo
+	}
+
+	official := false
+	for _, badge := range resp.ChannelBadges {
+		switch strings.ToUpper(badge) {
+		// A plain "VERIFIED" badge only confirms the channel's identity, not that it is an artist's.
+		case "OFFICIAL_ARTIST", "VERIFIED_ARTIST":
+			official = true
+		}
+	}
+
+	channel := strings.TrimSpace(resp.ChannelTitle)
+	switch {
+	case strings.HasSuffix(channel, " - Topic"):
+		official = true
+		if kind == domain.ResultKindUnknown {
+			kind = domain.ResultKindSong
+		}
+	case strings.HasSuffix(strings.ToUpper(channel), "VEVO"):
+		official = true
+		if kind == domain.ResultKindUnknown {
+			kind = domain.ResultKindVideo
+		}
+	}
+
+	return kind, official
+}
+
+func regionParam(market string) string {
+	if market == "" {
+		return ""
+	}
+	return "&regionCode=" + url.QueryEscape(market)
+}
+
 func (c *youtubeClient) CreatePlaylist(ctx context.Context, name, description, sessionID string) (string, string, error) {
 	authHeader, err := c.getAuthHeader(ctx, sessionID)
 	if err != nil {
@@ -233,26 +325,183 @@ func (c *youtubeClient) CreatePlaylist(ctx context.Context, name, description, s
 	return result.ID, playlistURL, nil
 }
 
-func (c *youtubeClient) AddVideosToPlaylist(ctx context.Context, playlistID string, videoIDs []string, sessionID string) error {
+// AddVideosToPlaylist inserts videos in chunks and reports the outcome of every video.
+// A chunk the service refuses with a 4xx is retried one video at a time, so only the
+// rejected videos fail. Any other failure, e.g. an expired token, a rate limit, a server
+// error or a timeout, stops the inserts: the error is returned with the results of the
+// videos handled before it.
+func (c *youtubeClient) AddVideosToPlaylist(ctx context.Context, playlistID string, videoIDs []string, sessionID string) ([]domain.VideoInsertResult, error) {
 	if len(videoIDs) == 0 {
-		return nil
+		return nil, nil
 	}
 
 	authHeader, err := c.getAuthHeader(ctx, sessionID)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	results := make([]domain.VideoInsertResult, 0, len(videoIDs))
+	for i := 0; i < len(videoIDs); i += addVideosChunkSize {
+		end := i + addVideosChunkSize
+		if end > len(videoIDs) {
+			end = len(videoIDs)
+		}
+		chunk := videoIDs[i:end]
+
+		chunkResults, err := c.addVideos(ctx, playlistID, chunk, authHeader)
+		switch {
+		case err == nil:
+			results = append(results, chunkResults...)
+		case !isVideoRejection(err):
+			return results, err
+		case len(chunk) == 1:
+			results = append(results, failedInserts(chunk, err)...)
+		default:
+			// One rejected video fails the whole request, so retry the chunk one video at a time.
+			log.Printf("[DEBUG] chunk insert into playlist %s rejected, retrying %d videos one by one: %v", playlistID, len(chunk), err)
+			for _, videoID := range chunk {
+				result, err := c.addVideo(ctx, playlistID, videoID, authHeader)
+				if err != nil {
+					return results, err
+				}
+				results = append(results, result)
+			}
+		}
+	}
+
+	return results, nil
+}
+
+func (c *youtubeClient) addVideo(ctx context.Context, playlistID, videoID, authHeader string) (domain.VideoInsertResult, error) {
+	results, err := c.addVideos(ctx, playlistID, []string{videoID}, authHeader)
+	if err != nil {
+		if isVideoRejection(err) {
+			return failedInserts([]string{videoID}, err)[0], nil
+		}
+		return domain.VideoInsertResult{}, err
 	}
+	return results[0], nil
+}
 
-	addURL := fmt.Sprintf("%s/v1/playlists/%s/videos", c.baseURL, playlistID)
+// statusError is a response from the youtube service with an unexpected status code.
+type statusError struct {
+	StatusCode int
+	Body       string
+}
 
-	reqBody := addVideosRequest{VideoIDs: videoIDs}
+func (e *statusError) Error() string {
+	return fmt.Sprintf("youtube service returned status %d: %s", e.StatusCode, e.Body)
+}
 
-	bodyBytes, err := json.Marshal(reqBody)
+// isVideoRejection reports whether the service refused the videos in the request
+// rather than the request itself.
+func isVideoRejection(err error) bool {
+	var statusErr *statusError
+	if !errors.As(err, &statusErr) {
+		return false
+	}
+	switch statusErr.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusRequestTimeout, http.StatusTooManyRequests:
+		return false
+	}
+	return statusErr.StatusCode >= 400 && statusErr.StatusCode < 500
+}
+
+func (c *youtubeClient) addVideos(ctx context.Context, playlistID string, videoIDs []string, authHeader string) ([]domain.VideoInsertResult, error) {
+	addURL := fmt.Sprintf("%s/v1/playlists/%s/videos", c.baseURL, url.PathEscape(playlistID))
+
+	bodyBytes, err := json.Marshal(addVideosRequest{VideoIDs: videoIDs})
 	if err != nil {
-		return fmt.Errorf("failed to marshal request: %w", err)
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, addURL, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", authHeader)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add videos to playlist: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusMultiStatus {
+		return nil, &statusError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	var result addVideosResponse
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &result); err != nil {
+			return nil, fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+
+	return toInsertResults(videoIDs, result.Results), nil
+}
+
+// toInsertResults returns one result per requested video. A successful response without
+// per-video results means every video was added. When results are reported, a video missing
+// from them may or may not be in the playlist, so it counts as failed.
+func toInsertResults(videoIDs []string, items []addVideoResultItem) []domain.VideoInsertResult {
+	if len(items) == 0 {
+		results := make([]domain.VideoInsertResult, len(videoIDs))
+		for i, videoID := range videoIDs {
+			results[i] = domain.VideoInsertResult{VideoID: videoID}
+		}
+		return results
+	}
+
+	outcomes := make(map[string]string, len(items))
+	for _, item := range items {
+		switch {
+		case !strings.EqualFold(item.Status, addVideoStatusFailed):
+			outcomes[item.VideoID] = ""
+		case item.Error != "":
+			outcomes[item.VideoID] = item.Error
+		default:
+			outcomes[item.VideoID] = "rejected by youtube service"
+		}
+	}
+
+	results := make([]domain.VideoInsertResult, len(videoIDs))
+	for i, videoID := range videoIDs {
+		outcome, ok := outcomes[videoID]
+		if !ok {
+			outcome = "not reported by youtube service"
+		}
+		results[i] = domain.VideoInsertResult{VideoID: videoID, Error: outcome}
+	}
+	return results
+}
+
+func failedInserts(videoIDs []string, err error) []domain.VideoInsertResult {
+	results := make([]domain.VideoInsertResult, len(videoIDs))
+	for i, videoID := range videoIDs {
+		results[i] = domain.VideoInsertResult{VideoID: videoID, Error: err.Error()}
+	}
+	return results
+}
+
+func (c *youtubeClient) ReplaceVideoInPlaylist(ctx context.Context, playlistID, oldVideoID, newVideoID, sessionID string) error {
+	authHeader, err := c.getAuthHeader(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+
+	replaceURL := fmt.Sprintf("%s/v1/playlists/%s/videos/%s", c.baseURL, url.PathEscape(playlistID), url.PathEscape(oldVideoID))
+
+	bodyBytes, err := json.Marshal(replaceVideoRequest{VideoID: newVideoID})
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, replaceURL, bytes.NewReader(bodyBytes))
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
@@ -263,11 +512,11 @@ func (c *youtubeClient) AddVideosToPlaylist(ctx context.Context, playlistID stri
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to add videos to playlist: %w", err)
+		return fmt.Errorf("failed to replace video in playlist: %w", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
 		body, _ := io.ReadAll(resp.Body)
 		return fmt.Errorf("youtube service returned status %d: %s", resp.StatusCode, string(body))
 	}
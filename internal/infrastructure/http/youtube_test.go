@@ -0,0 +1,270 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/config"
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/domain"
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/infrastructure/redis"
+)
+
+type staticSessionStore struct{}
+
+func (staticSessionStore) GetSpotifyToken(ctx context.Context, sessionID string) (*redis.SpotifyToken, error) {
+	return &redis.SpotifyToken{AccessToken: "spotify-token"}, nil
+}
+
+func (staticSessionStore) GetYouTubeToken(ctx context.Context, spotifySessionID string) (*redis.YouTubeToken, error) {
+	return &redis.YouTubeToken{AccessToken: "youtube-token"}, nil
+}
+
+// playlistServer answers every insert request with respond and records the videos of each request.
+type playlistServer struct {
+	mu       sync.Mutex
+	requests [][]string
+	respond  func(w http.ResponseWriter, request int, videoIDs []string)
+}
+
+func (s *playlistServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req addVideosRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.mu.Lock()
+	s.requests = append(s.requests, req.VideoIDs)
+	request := len(s.requests)
+	s.mu.Unlock()
+	s.respond(w, request, req.VideoIDs)
+}
+
+func (s *playlistServer) received() [][]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.requests)
+}
+
+func addedResponse(w http.ResponseWriter, videoIDs []string) {
+	var resp addVideosResponse
+	for _, videoID := range videoIDs {
+		resp.Results = append(resp.Results, addVideoResultItem{VideoID: videoID, Status: "ADDED"})
+	}
+	json.NewEncoder(w).Encode(resp)
+}
+
+func newPlaylistTestClient(t *testing.T, server *playlistServer, timeout time.Duration) YouTubeClient {
+	t.Helper()
+	srv := httptest.NewServer(server)
+	t.Cleanup(srv.Close)
+	return NewYouTubeClient(config.ServiceConfig{BaseURL: srv.URL, Timeout: timeout}, staticSessionStore{})
+}
+
+func videoIDs(n int) []string {
+	ids := make([]string, n)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("yt%d", i)
+	}
+	return ids
+}
+
+func failedVideos(results []domain.VideoInsertResult) map[string]string {
+	failed := map[string]string{}
+	for _, result := range results {
+		if result.Failed() {
+			failed[result.VideoID] = result.Error
+		}
+	}
+	return failed
+}
+
+func TestAddVideosToPlaylist_Chunks(t *testing.T) {
+	server := &playlistServer{respond: func(w http.ResponseWriter, request int, videoIDs []string) {
+		addedResponse(w, videoIDs)
+	}}
+	client := newPlaylistTestClient(t, server, time.Second)
+
+	results, err := client.AddVideosToPlaylist(context.Background(), "playlist", videoIDs(120), "session")
+	if err != nil {
+		t.Fatalf("AddVideosToPlaylist() error: %v", err)
+	}
+
+	var sizes []int
+	for _, request := range server.received() {
+		sizes = append(sizes, len(request))
+	}
+	if !slices.Equal(sizes, []int{50, 50, 20}) {
+		t.Errorf("chunk sizes = %v, want [50 50 20]", sizes)
+	}
+	if len(results) != 120 || len(failedVideos(results)) != 0 {
+		t.Errorf("expected 120 added videos, got %d results with %d failures", len(results), len(failedVideos(results)))
+	}
+}
+
+func TestAddVideosToPlaylist_SplitsRejectedChunk(t *testing.T) {
+	server := &playlistServer{respond: func(w http.ResponseWriter, request int, videoIDs []string) {
+		if slices.Contains(videoIDs, "yt1") {
+			http.Error(w, "video unavailable", http.StatusBadRequest)
+			return
+		}
+		addedResponse(w, videoIDs)
+	}}
+	client := newPlaylistTestClient(t, server, time.Second)
+
+	results, err := client.AddVideosToPlaylist(context.Background(), "playlist", videoIDs(3), "session")
+	if err != nil {
+		t.Fatalf("AddVideosToPlaylist() error: %v", err)
+	}
+
+	if requests := len(server.received()); requests != 4 {
+		t.Errorf("expected the chunk and three single-video retries, got %d requests", requests)
+	}
+	failed := failedVideos(results)
+	if len(results) != 3 || len(failed) != 1 || failed["yt1"] == "" {
+		t.Errorf("expected only yt1 to fail, got %v", results)
+	}
+}
+
+func TestAddVideosToPlaylist_FailsFast(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		timeout bool
+	}{
+		{"unauthorized", http.StatusUnauthorized, false},
+		{"forbidden", http.StatusForbidden, false},
+		{"rate limited", http.StatusTooManyRequests, false},
+		{"server error", http.StatusServiceUnavailable, false},
+		{"timeout", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &playlistServer{respond: func(w http.ResponseWriter, request int, videoIDs []string) {
+				if request == 1 {
+					addedResponse(w, videoIDs)
+					return
+				}
+				if tt.timeout {
+					time.Sleep(200 * time.Millisecond)
+					addedResponse(w, videoIDs)
+					return
+				}
+				http.Error(w, "request refused", tt.status)
+			}}
+			client := newPlaylistTestClient(t, server, 100*time.Millisecond)
+
+			results, err := client.AddVideosToPlaylist(context.Background(), "playlist", videoIDs(120), "session")
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+
+			if requests := len(server.received()); requests != 2 {
+				t.Errorf("expected inserts to stop after the failed chunk, got %d requests", requests)
+			}
+			if len(results) != 50 || len(failedVideos(results)) != 0 {
+				t.Errorf("expected the 50 videos of the first chunk to be reported as added, got %d results", len(results))
+			}
+		})
+	}
+}
+
+func TestAddVideosToPlaylist_PartialResponse(t *testing.T) {
+	server := &playlistServer{respond: func(w http.ResponseWriter, request int, videoIDs []string) {
+		json.NewEncoder(w).Encode(addVideosResponse{Results: []addVideoResultItem{
+			{VideoID: "yt0", Status: "ADDED"},
+			{VideoID: "yt1", Status: "FAILED"},
+		}})
+	}}
+	client := newPlaylistTestClient(t, server, time.Second)
+
+	results, err := client.AddVideosToPlaylist(context.Background(), "playlist", videoIDs(3), "session")
+	if err != nil {
+		t.Fatalf("AddVideosToPlaylist() error: %v", err)
+	}
+
+	failed := failedVideos(results)
+	want := map[string]string{
+		"yt1": "rejected by youtube service",
+		"yt2": "not reported by youtube service",
+	}
+	if len(results) != 3 || len(failed) != len(want) || failed["yt1"] != want["yt1"] || failed["yt2"] != want["yt2"] {
+		t.Errorf("failed = %v, want %v", failed, want)
+	}
+}
+
+func TestAddVideosToPlaylist_EmptySuccessResponse(t *testing.T) {
+	server := &playlistServer{respond: func(w http.ResponseWriter, request int, videoIDs []string) {
+		w.WriteHeader(http.StatusCreated)
+	}}
+	client := newPlaylistTestClient(t, server, time.Second)
+
+	results, err := client.AddVideosToPlaylist(context.Background(), "playlist", videoIDs(3), "session")
+	if err != nil {
+		t.Fatalf("AddVideosToPlaylist() error: %v", err)
+	}
+
+	if len(results) != 3 || len(failedVideos(results)) != 0 {
+		t.Errorf("expected a bare 2xx to report every video as added, got %v", results)
+	}
+}
+
+func TestClassifyResult(t *testing.T) {
+	tests := []struct {
+		name         string
+		resp         youtubeSearchResponse
+		wantKind     domain.ResultKind
+		wantOfficial bool
+	}{
+		{"song result type", youtubeSearchResponse{ResultType: "song", ChannelTitle: "Some Uploader"}, domain.ResultKindSong, false},
+		{"video result type", youtubeSearchResponse{ResultType: "VIDEO", ChannelTitle: "Some Uploader"}, domain.ResultKindVideo, false},
+		{"official artist badge", youtubeSearchResponse{ChannelTitle: "Queen Official", ChannelBadges: []string{"OFFICIAL_ARTIST"}}, domain.ResultKindUnknown, true},
+		{"verified artist badge", youtubeSearchResponse{ChannelTitle: "Queen Official", ChannelBadges: []string{"verified_artist"}}, domain.ResultKindUnknown, true},
+		{"generic verified badge", youtubeSearchResponse{ChannelTitle: "Lyrics Hub", ChannelBadges: []string{"VERIFIED"}}, domain.ResultKindUnknown, false},
+		{"topic channel", youtubeSearchResponse{ChannelTitle: "Queen - Topic"}, domain.ResultKindSong, true},
+		{"vevo channel", youtubeSearchResponse{ChannelTitle: "QueenVEVO"}, domain.ResultKindVideo, true},
+		{"result type wins over channel", youtubeSearchResponse{ResultType: "video", ChannelTitle: "Queen - Topic"}, domain.ResultKindVideo, true},
+		{"regular channel", youtubeSearchResponse{ChannelTitle: "QueenFan99"}, domain.ResultKindUnknown, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kind, official := classifyResult(tt.resp)
+			if kind != tt.wantKind || official != tt.wantOfficial {
+				t.Errorf("classifyResult() = %q/%v, want %q/%v", kind, official, tt.wantKind, tt.wantOfficial)
+			}
+		})
+	}
+}
+
+func TestSearchTrack_ReadsAllResults(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(youtubeMusicSearchResponse{Results: []youtubeSearchResponse{
+			{VideoID: "yt1", Title: "Bohemian Rhapsody", ChannelID: "UC-queen", ChannelTitle: "Queen Official"},
+			{VideoID: "yt2", Title: "Bohemian Rhapsody (Cover)", ChannelID: "UC-fan", ChannelTitle: "QueenFan99"},
+			{VideoID: "yt3", Title: "Bohemian Rhapsody", ChannelID: "UC-topic", ChannelTitle: "Queen - Topic"},
+		}})
+	}))
+	t.Cleanup(srv.Close)
+	client := NewYouTubeClient(config.ServiceConfig{BaseURL: srv.URL, Timeout: time.Second}, staticSessionStore{})
+
+	tracks, err := client.SearchTrack(context.Background(), "Bohemian Rhapsody", "Queen", "", "session")
+	if err != nil {
+		t.Fatalf("SearchTrack() error: %v", err)
+	}
+	var got []string
+	for _, track := range tracks {
+		got = append(got, track.PlatformID+"/"+track.ChannelID+"/"+track.Artist)
+	}
+	want := []string{"yt1/UC-queen/Queen Official", "yt2/UC-fan/QueenFan99", "yt3/UC-topic/Queen - Topic"}
+	if !slices.Equal(got, want) {
+		t.Errorf("tracks = %v, want %v", got, want)
+	}
+}
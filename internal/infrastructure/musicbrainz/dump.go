@@ -0,0 +1,170 @@
+package musicbrainz
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/application"
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/domain"
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/metrics"
+)
+
+const (
+	providerName        = "musicbrainz"
+	durationToleranceMs = 5000
+	maxLineSize         = 1024 * 1024
+)
+
+type recording struct {
+	ISRCs   []string `json:"isrcs"`
+	Title   string   `json:"title"`
+	Artists []string `json:"artists"`
+	Length  int      `json:"length"`
+}
+
+type dumpEnricher struct {
+	byISRC  map[string]*recording
+	byTitle map[string][]*recording
+}
+
+func NewDumpEnricher(path string) (application.Enricher, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open musicbrainz dump: %w", err)
+	}
+	defer file.Close()
+
+	e := &dumpEnricher{
+		byISRC:  make(map[string]*recording),
+		byTitle: make(map[string][]*recording),
+	}
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		var rec recording
+		if err := json.Unmarshal([]byte(text), &rec); err != nil {
+			return nil, fmt.Errorf("failed to parse musicbrainz dump line %d: %w", line, err)
+		}
+		e.add(&rec)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read musicbrainz dump: %w", err)
+	}
+
+	return e, nil
+}
+
+func (e *dumpEnricher) add(rec *recording) {
+	if rec.Title == "" || len(rec.Artists) == 0 {
+		return
+	}
+
+	for _, isrc := range rec.ISRCs {
+		e.byISRC[normalizeISRC(isrc)] = rec
+	}
+	for _, artist := range rec.Artists {
+		key := titleKey(rec.Title, artist)
+		e.byTitle[key] = append(e.byTitle[key], rec)
+	}
+}
+
+// Enrich only fills in what the track is missing. The ISRC is only copied when a single
+// recording matches the title, artist and duration, since several close matches may be
+// different releases of the song.
+func (e *dumpEnricher) Enrich(ctx context.Context, track *domain.Track) error {
+	rec, unique := e.lookup(track)
+	if rec == nil {
+		metrics.EnrichmentLookups.WithLabelValues(providerName, "miss").Inc()
+		return nil
+	}
+	metrics.EnrichmentLookups.WithLabelValues(providerName, "hit").Inc()
+
+	if track.ISRC == "" && unique && len(rec.ISRCs) > 0 {
+		track.WithISRC(normalizeISRC(rec.ISRCs[0]))
+	}
+	if track.DurationMs == 0 {
+		track.WithDuration(rec.Length)
+	}
+	if len(track.Artists) == 0 {
+		track.WithArtists(rec.Artists)
+	}
+
+	return nil
+}
+
+// lookup finds the recording for the track and reports whether it was the only one
+// matching by title, artist and duration.
+func (e *dumpEnricher) lookup(track *domain.Track) (*recording, bool) {
+	if track.ISRC != "" {
+		if rec, ok := e.byISRC[normalizeISRC(track.ISRC)]; ok {
+			return rec, true
+		}
+	}
+
+	for _, artist := range splitArtists(track.Artist) {
+		if rec, matches := closestByDuration(e.byTitle[titleKey(track.Name, artist)], track.DurationMs); rec != nil {
+			return rec, matches == 1
+		}
+	}
+	return nil, false
+}
+
+// closestByDuration picks the recording whose length is closest to the track's and counts the
+// recordings within tolerance. Title and artist alone are too weak a match, so both lengths
+// must be known.
+func closestByDuration(recs []*recording, durationMs int) (*recording, int) {
+	if len(recs) == 0 || durationMs == 0 {
+		return nil, 0
+	}
+
+	var best *recording
+	bestDiff := durationToleranceMs + 1
+	matches := 0
+	for _, rec := range recs {
+		if rec.Length == 0 {
+			continue
+		}
+		diff := rec.Length - durationMs
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff > durationToleranceMs {
+			continue
+		}
+		matches++
+		if diff < bestDiff {
+			best, bestDiff = rec, diff
+		}
+	}
+	return best, matches
+}
+
+func splitArtists(artist string) []string {
+	artists := []string{artist}
+	for _, part := range strings.FieldsFunc(artist, func(r rune) bool { return r == ',' || r == '&' || r == ';' }) {
+		if part = strings.TrimSpace(part); part != "" && part != artist {
+			artists = append(artists, part)
+		}
+	}
+	return artists
+}
+
+func titleKey(title, artist string) string {
+	return domain.NormalizeText(title) + "|" + domain.NormalizeText(artist)
+}
+
+func normalizeISRC(isrc string) string {
+	return strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(isrc), "-", ""))
+}
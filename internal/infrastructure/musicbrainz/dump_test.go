@@ -0,0 +1,103 @@
+package musicbrainz
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/application"
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/domain"
+)
+
+const testDump = `{"isrcs": ["GB-UM7-10-29604"], "title": "Bohemian Rhapsody", "artists": ["Queen"], "length": 354000}
+{"isrcs": ["USUM71703861"], "title": "Under Pressure", "artists": ["Queen", "David Bowie"], "length": 248000}
+{"isrcs": ["GBAYE0601498"], "title": "Under Pressure", "artists": ["Queen", "David Bowie"], "length": 236000}
+{"isrcs": ["GBUM71507409"], "title": "Radio Ga Ga", "artists": ["Queen"], "length": 0}
+{"isrcs": ["GBUM71029601"], "title": "Somebody to Love", "artists": ["Queen"], "length": 296000}
+{"isrcs": ["GBUM71029602"], "title": "Somebody to Love", "artists": ["Queen"], "length": 299000}
+`
+
+func newTestEnricher(t *testing.T) application.Enricher {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "dump.jsonl")
+	if err := os.WriteFile(path, []byte(testDump), 0o600); err != nil {
+		t.Fatalf("failed to write dump: %v", err)
+	}
+	enricher, err := NewDumpEnricher(path)
+	if err != nil {
+		t.Fatalf("NewDumpEnricher() error: %v", err)
+	}
+	return enricher
+}
+
+func TestDumpEnricher_ISRCMatch(t *testing.T) {
+	enricher := newTestEnricher(t)
+	track, _ := domain.NewTrack("Bohemian Rhapsody - Remastered", "Queen", domain.PlatformSpotify, "sp1")
+	track.WithISRC("GBUM71029604")
+
+	if err := enricher.Enrich(context.Background(), track); err != nil {
+		t.Fatalf("Enrich() error: %v", err)
+	}
+
+	if track.DurationMs != 354000 || !slices.Equal(track.Artists, []string{"Queen"}) {
+		t.Errorf("duration/artists = %d/%v, want 354000/[Queen]", track.DurationMs, track.Artists)
+	}
+}
+
+func TestDumpEnricher_TitleMatch(t *testing.T) {
+	tests := []struct {
+		name        string
+		title       string
+		artist      string
+		durationMs  int
+		wantISRC    string
+		wantArtists []string
+	}{
+		{"single recording within tolerance", "Under Pressure", "Queen & David Bowie", 247000, "USUM71703861", []string{"Queen", "David Bowie"}},
+		{"several recordings within tolerance", "Somebody to Love", "Queen", 297000, "", []string{"Queen"}},
+		{"unknown track length", "Under Pressure", "Queen", 0, "", nil},
+		{"length outside tolerance", "Under Pressure", "Queen", 300000, "", nil},
+		{"unknown recording length", "Radio Ga Ga", "Queen", 343000, "", nil},
+	}
+
+	enricher := newTestEnricher(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			track, _ := domain.NewTrack(tt.title, tt.artist, domain.PlatformSpotify, "sp1")
+			track.WithDuration(tt.durationMs)
+
+			if err := enricher.Enrich(context.Background(), track); err != nil {
+				t.Fatalf("Enrich() error: %v", err)
+			}
+
+			if !slices.Equal(track.Artists, tt.wantArtists) {
+				t.Errorf("artists = %v, want %v", track.Artists, tt.wantArtists)
+			}
+			if track.ISRC != tt.wantISRC {
+				t.Errorf("ISRC = %q, want %q", track.ISRC, tt.wantISRC)
+			}
+			if track.DurationMs != tt.durationMs {
+				t.Errorf("duration = %d, want the track's own %d", track.DurationMs, tt.durationMs)
+			}
+		})
+	}
+}
+
+func TestDumpEnricher_KeepsExistingArtists(t *testing.T) {
+	enricher := newTestEnricher(t)
+	track, _ := domain.NewTrack("Under Pressure", "Queen", domain.PlatformSpotify, "sp1")
+	track.WithISRC("USUM71703861").WithArtists([]string{"Queen", "David Bowie", "Freddie Mercury"})
+
+	if err := enricher.Enrich(context.Background(), track); err != nil {
+		t.Fatalf("Enrich() error: %v", err)
+	}
+
+	if !slices.Equal(track.Artists, []string{"Queen", "David Bowie", "Freddie Mercury"}) {
+		t.Errorf("expected the source's artists to be kept, got %v", track.Artists)
+	}
+	if track.DurationMs != 248000 {
+		t.Errorf("duration = %d, want 248000", track.DurationMs)
+	}
+}
@@ -0,0 +1,78 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/domain"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	checkpointKeySuffix = ":checkpoint"
+	checkpointTTL       = 24 * time.Hour
+)
+
+// CheckpointStore keeps the match results of a conversion while it is still matching,
+// so a redelivered job only searches for the tracks that were not finished.
+type CheckpointStore interface {
+	SaveMatch(ctx context.Context, conversionID string, match *domain.TrackMatch) error
+	LoadMatches(ctx context.Context, conversionID string) (map[string]*domain.TrackMatch, error)
+	Delete(ctx context.Context, conversionID string) error
+}
+
+type checkpointStore struct {
+	rdb *redis.Client
+}
+
+func NewCheckpointStore(client Client) CheckpointStore {
+	return &checkpointStore{rdb: client.GetRDB()}
+}
+
+func checkpointKey(conversionID string) string {
+	return statusKeyPrefix + conversionID + checkpointKeySuffix
+}
+
+func (s *checkpointStore) SaveMatch(ctx context.Context, conversionID string, match *domain.TrackMatch) error {
+	data, err := json.Marshal(match)
+	if err != nil {
+		return fmt.Errorf("failed to marshal checkpoint: %w", err)
+	}
+
+	key := checkpointKey(conversionID)
+	pipe := s.rdb.TxPipeline()
+	pipe.HSet(ctx, key, match.SourceTrack.PlatformID, data)
+	pipe.Expire(ctx, key, checkpointTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+
+	return nil
+}
+
+func (s *checkpointStore) LoadMatches(ctx context.Context, conversionID string) (map[string]*domain.TrackMatch, error) {
+	fields, err := s.rdb.HGetAll(ctx, checkpointKey(conversionID)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load checkpoint: %w", err)
+	}
+
+	matches := make(map[string]*domain.TrackMatch, len(fields))
+	for sourceID, data := range fields {
+		var match domain.TrackMatch
+		if err := json.Unmarshal([]byte(data), &match); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal checkpoint: %w", err)
+		}
+		matches[sourceID] = &match
+	}
+
+	return matches, nil
+}
+
+func (s *checkpointStore) Delete(ctx context.Context, conversionID string) error {
+	if err := s.rdb.Del(ctx, checkpointKey(conversionID)).Err(); err != nil {
+		return fmt.Errorf("failed to delete checkpoint: %w", err)
+	}
+	return nil
+}
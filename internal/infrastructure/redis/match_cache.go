@@ -0,0 +1,107 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/config"
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/domain"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	matchCacheISRCPrefix   = "match:isrc:"
+	matchCacheSearchPrefix = "match:search:"
+)
+
+type MatchCache interface {
+	GetISRC(ctx context.Context, isrc string) (track *domain.Track, found bool, err error)
+	SetISRC(ctx context.Context, isrc string, track *domain.Track) error
+	GetSearch(ctx context.Context, fingerprint string) (tracks []*domain.Track, found bool, err error)
+	SetSearch(ctx context.Context, fingerprint string, tracks []*domain.Track) error
+}
+
+type cachedResult struct {
+	Tracks   []*domain.Track `json:"tracks,omitempty"`
+	NotFound bool            `json:"notFound,omitempty"`
+}
+
+type matchCache struct {
+	rdb         *redis.Client
+	ttl         time.Duration
+	negativeTTL time.Duration
+}
+
+func NewMatchCache(client Client, cfg config.MatchCacheConfig) MatchCache {
+	return &matchCache{
+		rdb:         client.GetRDB(),
+		ttl:         cfg.TTL,
+		negativeTTL: cfg.NegativeTTL,
+	}
+}
+
+func (c *matchCache) GetISRC(ctx context.Context, isrc string) (*domain.Track, bool, error) {
+	result, found, err := c.get(ctx, matchCacheISRCPrefix+isrc)
+	if err != nil || !found || result.NotFound || len(result.Tracks) == 0 {
+		return nil, found, err
+	}
+	return result.Tracks[0], true, nil
+}
+
+func (c *matchCache) SetISRC(ctx context.Context, isrc string, track *domain.Track) error {
+	var tracks []*domain.Track
+	if track != nil {
+		tracks = []*domain.Track{track}
+	}
+	return c.set(ctx, matchCacheISRCPrefix+isrc, tracks)
+}
+
+func (c *matchCache) GetSearch(ctx context.Context, fingerprint string) ([]*domain.Track, bool, error) {
+	result, found, err := c.get(ctx, matchCacheSearchPrefix+fingerprint)
+	if err != nil || !found {
+		return nil, found, err
+	}
+	return result.Tracks, true, nil
+}
+
+func (c *matchCache) SetSearch(ctx context.Context, fingerprint string, tracks []*domain.Track) error {
+	return c.set(ctx, matchCacheSearchPrefix+fingerprint, tracks)
+}
+
+func (c *matchCache) get(ctx context.Context, key string) (*cachedResult, bool, error) {
+	data, err := c.rdb.Get(ctx, key).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to get cached match: %w", err)
+	}
+
+	var result cachedResult
+	if err := json.Unmarshal([]byte(data), &result); err != nil {
+		return nil, false, fmt.Errorf("failed to unmarshal cached match: %w", err)
+	}
+
+	return &result, true, nil
+}
+
+func (c *matchCache) set(ctx context.Context, key string, tracks []*domain.Track) error {
+	result := cachedResult{Tracks: tracks, NotFound: len(tracks) == 0}
+	ttl := c.ttl
+	if result.NotFound {
+		ttl = c.negativeTTL
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cached match: %w", err)
+	}
+
+	if err := c.rdb.Set(ctx, key, data, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to cache match: %w", err)
+	}
+
+	return nil
+}
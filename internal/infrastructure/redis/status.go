@@ -17,17 +17,19 @@ const (
 )
 
 type ConversionStatusData struct {
-	JobID                     string                   `json:"jobId"`
-	Status                    domain.ConversionStatus  `json:"status"`
-	Progress                  int                      `json:"progress"`
-	TotalTracks               int                      `json:"totalTracks"`
-	ProcessedTracks           int                      `json:"processedTracks"`
-	MatchedTracks             int                      `json:"matchedTracks"`
-	FailedTracks              int                      `json:"failedTracks"`
-	EstimatedSecondsRemaining int                      `json:"estimatedSecondsRemaining"`
-	TargetPlaylistURL         string                   `json:"targetPlaylistUrl,omitempty"`
-	Error                     string                   `json:"error,omitempty"`
-	UpdatedAt                 time.Time                `json:"updatedAt"`
+	JobID                     string                  `json:"jobId"`
+	Status                    domain.ConversionStatus `json:"status"`
+	Progress                  int                     `json:"progress"`
+	TotalTracks               int                     `json:"totalTracks"`
+	ProcessedTracks           int                     `json:"processedTracks"`
+	MatchedTracks             int                     `json:"matchedTracks"`
+	ReviewTracks              int                     `json:"reviewTracks"`
+	FailedTracks              int                     `json:"failedTracks"`
+	FailedInserts             int                     `json:"failedInserts"`
+	EstimatedSecondsRemaining int                     `json:"estimatedSecondsRemaining"`
+	TargetPlaylistURL         string                  `json:"targetPlaylistUrl,omitempty"`
+	Error                     string                  `json:"error,omitempty"`
+	UpdatedAt                 time.Time               `json:"updatedAt"`
 }
 
 type StatusStore interface {
@@ -99,7 +101,9 @@ func NewStatusFromConversion(c *domain.Conversion) *ConversionStatusData {
 		TotalTracks:     c.TotalTracks,
 		ProcessedTracks: c.ProcessedTracks,
 		MatchedTracks:   c.MatchedTracks,
+		ReviewTracks:    c.ReviewTracks,
 		FailedTracks:    c.FailedTracks,
+		FailedInserts:   c.FailedInserts,
 		UpdatedAt:       c.UpdatedAt,
 	}
 
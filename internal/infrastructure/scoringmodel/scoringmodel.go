@@ -0,0 +1,43 @@
+package scoringmodel
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/marcelovmendes/playswap/conversion-worker/internal/domain"
+)
+
+// Load reads a model written by Save. Models without a feature list predate it and must be retrained,
+// since the worker could not tell a feature the model ignores from one it never saw.
+func Load(path string) (*domain.ScoringModel, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scoring model: %w", err)
+	}
+
+	var model domain.ScoringModel
+	if err := json.Unmarshal(data, &model); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	if len(model.Features) == 0 {
+		return nil, fmt.Errorf("%s has no feature list, retrain it with cmd/trainer", path)
+	}
+	for feature := range model.Weights {
+		if !slices.Contains(model.Features, feature) {
+			return nil, fmt.Errorf("%s has a weight for %q, which is not in its feature list", path, feature)
+		}
+	}
+
+	return &model, nil
+}
+
+func Save(path string, model *domain.ScoringModel) error {
+	data, err := json.MarshalIndent(model, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
@@ -43,6 +43,119 @@ var (
 		Name: "conversion_tracks_not_found_total",
 		Help: "Total number of tracks not found",
 	})
+
+	TracksNeedsReview = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "conversion_tracks_needs_review_total",
+		Help: "Total number of tracks matched below the confidence floor",
+	})
+
+	JobsAwaitingReview = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "conversion_jobs_awaiting_review_total",
+		Help: "Total number of jobs paused for manual review",
+	})
+
+	JobsResumed = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "conversion_jobs_resumed_total",
+		Help: "Total number of redelivered jobs resumed from a saved stage",
+	}, []string{"stage"})
+
+	PlaylistInserts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "conversion_playlist_inserts_total",
+		Help: "Total number of videos inserted into target playlists by result",
+	}, []string{"result"})
+
+	JobsSkipped = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "conversion_jobs_skipped_total",
+		Help: "Total number of redelivered jobs that were not processed again",
+	}, []string{"reason"})
+
+	CheckpointedTracks = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "conversion_checkpointed_tracks_total",
+		Help: "Total number of tracks restored from a match checkpoint instead of being matched again",
+	})
+
+	ReviewDecisionsReceived = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "conversion_review_decisions_received_total",
+		Help: "Total number of review decision messages received from the queue",
+	})
+
+	DuplicateTracks = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "conversion_duplicate_tracks_total",
+		Help: "Total number of duplicate source tracks detected before matching",
+	}, []string{"policy"})
+
+	TrackSwaps = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "conversion_track_swaps_total",
+		Help: "Total number of track swaps applied to completed playlists",
+	}, []string{"result"})
+
+	JobISRCCoverage = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "conversion_job_isrc_coverage_ratio",
+		Help:    "Fraction of source tracks in a job that carry an ISRC",
+		Buckets: prometheus.LinearBuckets(0, 0.1, 11),
+	})
+
+	JobISRCHitRate = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "conversion_job_isrc_hit_ratio",
+		Help:    "Fraction of source tracks with an ISRC that were matched by ISRC search",
+		Buckets: prometheus.LinearBuckets(0, 0.1, 11),
+	})
+
+	EnrichmentLookups = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "conversion_enrichment_lookups_total",
+		Help: "Total number of metadata enrichment lookups",
+	}, []string{"provider", "result"})
+
+	ExperimentAssignments = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "conversion_experiment_assignments_total",
+		Help: "Total number of conversions assigned to each experiment variant",
+	}, []string{"experiment", "variant"})
+
+	ExperimentTracks = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "conversion_experiment_tracks_total",
+		Help: "Total number of matched tracks by experiment variant and outcome",
+	}, []string{"experiment", "variant", "result"})
+
+	ExperimentJobs = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "conversion_experiment_jobs_total",
+		Help: "Total number of finished conversions by experiment variant and status",
+	}, []string{"experiment", "variant", "status"})
+
+	MatchStrategyResults = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "conversion_match_strategy_results_total",
+		Help: "Total number of match strategy attempts by outcome",
+	}, []string{"strategy", "result"})
+
+	MatchStrategyDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "conversion_match_strategy_duration_seconds",
+		Help:    "Duration of a single match strategy attempt",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"strategy"})
+
+	ChannelListHits = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "conversion_channel_list_hits_total",
+		Help: "Total number of candidates boosted or excluded by channel lists",
+	}, []string{"list"})
+
+	CandidatesUnavailable = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "conversion_candidates_unavailable_total",
+		Help: "Total number of YouTube candidates skipped because the user cannot play them",
+	}, []string{"reason"})
+
+	MatchSearchesCoalesced = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "conversion_match_searches_coalesced_total",
+		Help: "Total number of YouTube lookups served by an identical in-flight request",
+	}, []string{"kind"})
+
+	MatchCacheHits = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "conversion_match_cache_hits_total",
+		Help: "Total number of match cache hits",
+	}, []string{"kind", "result"})
+
+	MatchCacheMisses = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "conversion_match_cache_misses_total",
+		Help: "Total number of match cache misses",
+	}, []string{"kind"})
 )
 
 func init() {
@@ -54,6 +167,28 @@ func init() {
 		JobsInProgress,
 		TracksMatched,
 		TracksNotFound,
+		TracksNeedsReview,
+		JobsAwaitingReview,
+		JobsResumed,
+		JobsSkipped,
+		PlaylistInserts,
+		CheckpointedTracks,
+		ReviewDecisionsReceived,
+		DuplicateTracks,
+		TrackSwaps,
+		JobISRCCoverage,
+		JobISRCHitRate,
+		EnrichmentLookups,
+		ExperimentAssignments,
+		ExperimentTracks,
+		ExperimentJobs,
+		MatchStrategyResults,
+		MatchStrategyDuration,
+		ChannelListHits,
+		CandidatesUnavailable,
+		MatchSearchesCoalesced,
+		MatchCacheHits,
+		MatchCacheMisses,
 	)
 }
 
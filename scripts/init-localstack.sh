@@ -80,4 +80,62 @@ else
     --time-to-live-specification Enabled=true,AttributeName=ttl
 fi
 
+if echo "$EXISTING_TABLES" | grep -qw "playswap-conversion-matches"; then
+  echo "Table playswap-conversion-matches already exists, skipping..."
+else
+  echo "Creating DynamoDB table: playswap-conversion-matches..."
+  aws dynamodb create-table \
+    --table-name playswap-conversion-matches \
+    --region "$REGION" \
+    --endpoint-url "$ENDPOINT" \
+    --attribute-definitions \
+      AttributeName=conversionId,AttributeType=S \
+      AttributeName=position,AttributeType=N \
+    --key-schema \
+      AttributeName=conversionId,KeyType=HASH \
+      AttributeName=position,KeyType=RANGE \
+    --billing-mode PAY_PER_REQUEST
+
+  echo "Enabling TTL on playswap-conversion-matches..."
+  aws dynamodb update-time-to-live \
+    --table-name playswap-conversion-matches \
+    --region "$REGION" \
+    --endpoint-url "$ENDPOINT" \
+    --time-to-live-specification Enabled=true,AttributeName=ttl
+fi
+
+if echo "$EXISTING_TABLES" | grep -qw "playswap-track-mappings"; then
+  echo "Table playswap-track-mappings already exists, skipping..."
+else
+  echo "Creating DynamoDB table: playswap-track-mappings..."
+  aws dynamodb create-table \
+    --table-name playswap-track-mappings \
+    --region "$REGION" \
+    --endpoint-url "$ENDPOINT" \
+    --attribute-definitions \
+      AttributeName=sourceKey,AttributeType=S \
+      AttributeName=targetId,AttributeType=S \
+    --key-schema \
+      AttributeName=sourceKey,KeyType=HASH \
+      AttributeName=targetId,KeyType=RANGE \
+    --billing-mode PAY_PER_REQUEST
+fi
+
+if echo "$EXISTING_TABLES" | grep -qw "playswap-channel-lists"; then
+  echo "Table playswap-channel-lists already exists, skipping..."
+else
+  echo "Creating DynamoDB table: playswap-channel-lists..."
+  aws dynamodb create-table \
+    --table-name playswap-channel-lists \
+    --region "$REGION" \
+    --endpoint-url "$ENDPOINT" \
+    --attribute-definitions \
+      AttributeName=scope,AttributeType=S \
+      AttributeName=entryKey,AttributeType=S \
+    --key-schema \
+      AttributeName=scope,KeyType=HASH \
+      AttributeName=entryKey,KeyType=RANGE \
+    --billing-mode PAY_PER_REQUEST
+fi
+
 echo "Done."